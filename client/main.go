@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
 	"log"
+	"os"
 
 	"github.com/alessandrr/temporal-go-docusign/docusign"
 	"github.com/google/uuid"
@@ -10,29 +13,100 @@ import (
 )
 
 func main() {
-	c, err := client.Dial(client.Options{})
+	inputFile := flag.String("input", "", "path to a JSON file containing the SendNdaWorkflow input")
+	user := flag.String("user", "", "ID of the DocuSign user to impersonate")
+	account := flag.String("account", "", "DocuSign account ID, defaults to the user's default account")
+	templateID := flag.String("template-id", "", "DocuSign template ID")
+	emailSubject := flag.String("subject", "", "email subject of the envelope")
+	signerName := flag.String("signer-name", "", "name of the signer")
+	signerEmail := flag.String("signer-email", "", "email of the signer")
+	roleName := flag.String("role", "Vendor", "template role of the signer")
+	clientUserID := flag.String("client-user-id", "", "client user ID of the signer for embedded signing")
+	fieldsType := flag.String("fields-type", "nda", "type of the template fields payload")
+	fields := flag.String("fields", "{}", "template fields payload as JSON")
+	expireAfterDays := flag.Int("expire-after-days", 0, "days after which the envelope expires, 0 keeps the account default")
+	reminderDelayDays := flag.Int("reminder-delay-days", 0, "days before the first reminder is sent, 0 keeps the account default")
+	reminderFrequencyDays := flag.Int("reminder-frequency-days", 0, "days between reminders")
+	voidOnExpiry := flag.Bool("void-on-expiry", false, "void the envelope when the signing deadline passes")
+	flag.Parse()
+
+	var input docusign.SendNdaWorkflowInput
+
+	if *inputFile != "" {
+		data, err := os.ReadFile(*inputFile)
+		if err != nil {
+			log.Fatalln("Unable to read input file", err)
+		}
+
+		if err := json.Unmarshal(data, &input); err != nil {
+			log.Fatalln("Unable to parse input file", err)
+		}
+	} else {
+		input = docusign.SendNdaWorkflowInput{
+			User:         docusign.DocusignUser{UserID: *user, AccountID: *account},
+			TemplateID:   *templateID,
+			EmailSubject: *emailSubject,
+			TemplateRoles: []docusign.TemplateRoles{
+				{
+					Email:        *signerEmail,
+					Name:         *signerName,
+					RoleName:     *roleName,
+					ClientUserID: *clientUserID,
+				},
+			},
+			TemplateFields: docusign.TemplateFieldWrapper{
+				Type: *fieldsType,
+				Data: json.RawMessage(*fields),
+			},
+			SigningSchedule: docusign.SigningSchedule{
+				ExpireAfterDays:       *expireAfterDays,
+				ReminderDelayDays:     *reminderDelayDays,
+				ReminderFrequencyDays: *reminderFrequencyDays,
+				VoidOnExpiry:          *voidOnExpiry,
+			},
+		}
+	}
+
+	if input.User.UserID == "" {
+		log.Fatalln("A DocuSign user is required")
+	}
+
+	config, err := docusign.ReadConfig()
+	if err != nil {
+		log.Fatalln("Unable to load config", err)
+	}
+
+	c, err := client.Dial(client.Options{
+		HostPort:  config.TemporalHost,
+		Namespace: config.TemporalNamespace,
+	})
 	if err != nil {
 		log.Fatalln("Unable to create client", err)
 	}
 	defer c.Close()
 
 	workflowOptions := client.StartWorkflowOptions{
-		TaskQueue: "nda",
+		TaskQueue: config.TaskQueue,
 		ID:        "nda-workflow-" + uuid.NewString(),
 	}
 
-	we, err := c.ExecuteWorkflow(context.Background(), workflowOptions, docusign.SendNdaWorkflow)
+	we, err := c.ExecuteWorkflow(context.Background(), workflowOptions, docusign.SendNdaWorkflow, input)
 	if err != nil {
 		log.Fatalln("Unable to execute workflow", err)
 	}
 
 	log.Println("Started workflow")
 
-	var result string
+	var result docusign.SendNdaResult
 	err = we.Get(context.Background(), &result)
 	if err != nil {
 		log.Fatalln("Unable to get wf result", err)
 	}
 
-	log.Println("Workflow result:", result)
+	resultJSON, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		log.Fatalln("Unable to format wf result", err)
+	}
+
+	log.Println("Workflow result:", string(resultJSON))
 }
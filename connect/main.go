@@ -0,0 +1,47 @@
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/alessandrr/temporal-go-docusign/docusign"
+	"go.temporal.io/sdk/client"
+)
+
+func main() {
+	config, err := docusign.ReadConfig()
+	if err != nil {
+		log.Fatalln("Unable to load config", err)
+	}
+
+	var hmacKeys [][]byte
+	for _, key := range config.ConnectHMACKeys {
+		hmacKeys = append(hmacKeys, []byte(key))
+	}
+
+	if len(hmacKeys) == 0 {
+		log.Fatal("At least one Connect HMAC key is required")
+	}
+
+	addr := config.ConnectListenAddr
+
+	c, err := client.Dial(client.Options{
+		HostPort:  config.TemporalHost,
+		Namespace: config.TemporalNamespace,
+	})
+	if err != nil {
+		log.Fatalln("Unable to create Temporal client", err)
+	}
+
+	defer c.Close()
+
+	mux := http.NewServeMux()
+	mux.Handle("/docusign/connect", docusign.NewConnectHandler(hmacKeys, c))
+
+	log.Println("Listening for DocuSign Connect events on", addr)
+
+	err = http.ListenAndServe(addr, mux)
+	if err != nil {
+		log.Fatalln("Connect receiver stopped", err)
+	}
+}
@@ -0,0 +1,144 @@
+package docusign
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+)
+
+const (
+	ValidationErrorType   = "DocusignValidationError"
+	UnauthorizedErrorType = "DocusignUnauthorized"
+	ForbiddenErrorType    = "DocusignForbidden"
+	NotFoundErrorType     = "DocusignNotFound"
+	RateLimitedErrorType  = "DocusignRateLimited"
+	ServerErrorType       = "DocusignServerError"
+	ClientErrorType       = "DocusignClientError"
+	NetworkErrorType      = "DocusignNetworkError"
+)
+
+const apiErrorMaxBodySize = 64 << 10
+
+type APIError struct {
+	StatusCode int           `json:"-"`
+	Status     string        `json:"-"`
+	Method     string        `json:"-"`
+	Path       string        `json:"-"`
+	Body       string        `json:"-"`
+	ErrorCode  string        `json:"errorCode"`
+	Message    string        `json:"message"`
+	RetryAfter time.Duration `json:"-"`
+}
+
+func newAPIError(req *http.Request, resp *http.Response) *APIError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, apiErrorMaxBodySize))
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		Body:       strings.TrimSpace(string(body)),
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		apiErr.RetryAfter = retryAfter(resp.Header)
+	}
+
+	if err := json.Unmarshal(body, apiErr); err != nil {
+		apiErr.ErrorCode = ""
+		apiErr.Message = ""
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	detail := e.Body
+	if e.ErrorCode != "" {
+		detail = e.ErrorCode
+		if e.Message != "" {
+			detail += ": " + e.Message
+		}
+	}
+
+	return fmt.Sprintf("%s %s failed with status %s: %s", e.Method, e.Path, e.Status, detail)
+}
+
+func (e *APIError) Retryable() bool {
+	switch {
+	case e.StatusCode == http.StatusTooManyRequests,
+		e.StatusCode == http.StatusRequestTimeout,
+		e.StatusCode == http.StatusUnauthorized,
+		e.StatusCode >= 500:
+		return true
+	}
+
+	return false
+}
+
+func (e *APIError) ErrorType() string {
+	switch {
+	case e.StatusCode == http.StatusBadRequest:
+		return ValidationErrorType
+	case e.StatusCode == http.StatusUnauthorized:
+		return UnauthorizedErrorType
+	case e.StatusCode == http.StatusForbidden:
+		return ForbiddenErrorType
+	case e.StatusCode == http.StatusNotFound:
+		return NotFoundErrorType
+	case e.StatusCode == http.StatusTooManyRequests:
+		return RateLimitedErrorType
+	case e.StatusCode >= 500:
+		return ServerErrorType
+	}
+
+	return ClientErrorType
+}
+
+type NetworkError struct {
+	Method string
+	Path   string
+	Err    error
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("%s %s failed: %s", e.Method, e.Path, e.Err)
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+func apiApplicationError(err error) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return temporal.NewApplicationErrorWithOptions(err.Error(), apiErr.ErrorType(), temporal.ApplicationErrorOptions{
+			NonRetryable:   !apiErr.Retryable(),
+			Cause:          err,
+			Details:        []interface{}{apiErr.ErrorCode, apiErr.StatusCode},
+			NextRetryDelay: apiErr.RetryAfter,
+		})
+	}
+
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return temporal.NewApplicationErrorWithOptions(err.Error(), RateLimitedErrorType, temporal.ApplicationErrorOptions{
+			Cause:          err,
+			NextRetryDelay: rateLimitErr.RetryAfter,
+		})
+	}
+
+	var networkErr *NetworkError
+	if errors.As(err, &networkErr) {
+		return temporal.NewApplicationErrorWithCause(err.Error(), NetworkErrorType, err)
+	}
+
+	return err
+}
@@ -0,0 +1,77 @@
+package docusign
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"go.temporal.io/sdk/temporal"
+)
+
+const envelopeDocumentPath = "/restapi/v2.1/accounts/%s/envelopes/%s/documents/%s"
+
+const (
+	CombinedDocumentKind    = "combined"
+	CertificateDocumentKind = "certificate"
+)
+
+const ArchiveNotConfiguredErrorType = "DocusignArchiveNotConfigured"
+
+type ArchivedDocument struct {
+	Kind string `json:"kind"`
+	StoredDocument
+}
+
+func (s *Activities) ArchiveEnvelopeDocuments(ctx context.Context, envelopeID string, user DocusignUser) ([]ArchivedDocument, error) {
+	if s.documents == nil {
+		return nil, temporal.NewNonRetryableApplicationError("no document store configured", ArchiveNotConfiguredErrorType, nil)
+	}
+
+	client, err := s.clientFor(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	archived := make([]ArchivedDocument, 0, 2)
+	for _, kind := range []string{CombinedDocumentKind, CertificateDocumentKind} {
+		document, err := s.archiveEnvelopeDocument(ctx, client, envelopeID, kind)
+		if err != nil {
+			return nil, err
+		}
+
+		archived = append(archived, document)
+	}
+
+	return archived, nil
+}
+
+func (s *Activities) archiveEnvelopeDocument(ctx context.Context, client *AccountClient, envelopeID string, kind string) (ArchivedDocument, error) {
+	req, err := client.NewRequest(
+		ctx,
+		"GET",
+		fmt.Sprintf(envelopeDocumentPath, client.AccountID, envelopeID, kind),
+		nil,
+	)
+	if err != nil {
+		return ArchivedDocument{}, err
+	}
+	req.Header.Set("Accept", "application/pdf")
+
+	resp, err := s.do(client, req)
+	if err != nil {
+		return ArchivedDocument{}, err
+	}
+
+	defer resp.Body.Close()
+
+	key := path.Join(client.AccountID, envelopeID, kind+".pdf")
+	stored, err := s.documents.Put(ctx, key, resp.Body)
+	if err != nil {
+		return ArchivedDocument{}, fmt.Errorf("storing %s document of envelope %s: %w", kind, envelopeID, err)
+	}
+
+	return ArchivedDocument{
+		Kind:           kind,
+		StoredDocument: stored,
+	}, nil
+}
@@ -1,7 +1,9 @@
 package docusign
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,16 +12,26 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt"
+	"golang.org/x/sync/singleflight"
 )
 
-type DocusignUser string
+type DocusignUser struct {
+	UserID    string `json:"userId"`
+	AccountID string `json:"accountId,omitempty"`
+}
 
-const (
-	DocusignTestUser DocusignUser = "4e3a9121-335b-48dc-a20e-a0f46f4a277c"
-)
+func (u DocusignUser) String() string {
+	if u.AccountID == "" {
+		return u.UserID
+	}
+
+	return u.UserID + "@" + u.AccountID
+}
 
 const DS_JWT_BUFFER = 5 * time.Minute
 
+const authRefreshTimeout = 30 * time.Second
+
 type DocusignUserInfo struct {
 	Email    string                `json:"email"`
 	Accounts []DocusignAccountInfo `json:"accounts"`
@@ -40,6 +52,14 @@ type DocusignUserCacheEntry struct {
 	TTL         time.Duration
 }
 
+func (e DocusignUserCacheEntry) Remaining() time.Duration {
+	return e.TTL - time.Since(e.CreatedAt)
+}
+
+func (e DocusignUserCacheEntry) Expired() bool {
+	return e.Remaining() <= 0
+}
+
 type AccessToken struct {
 	Token string `json:"access_token"`
 	Type  string `json:"token_type"`
@@ -47,70 +67,177 @@ type AccessToken struct {
 }
 
 type APIClient struct {
-	BaseURL    string
-	AuthHeader http.Header
-	Client     *http.Client
+	Client      *http.Client
+	RateLimiter *RateLimiter
 }
 
-func NewAPIClient(header http.Header, client *http.Client) *APIClient {
+func NewAPIClient(client *http.Client, rateLimiter *RateLimiter) *APIClient {
 	return &APIClient{
-		AuthHeader: header,
-		Client:     client,
+		Client:      client,
+		RateLimiter: rateLimiter,
 	}
 }
 
-type AuthInfoUpdater interface {
-	UpdateAuthInfo(user DocusignUser) (DocusignUserCacheEntry, error)
+type AccountClient struct {
+	AccountID   string
+	BaseURL     string
+	accessToken string
+	client      *http.Client
+	rateLimiter *RateLimiter
+	invalidate  func()
+}
+
+func (c *AccountClient) NewRequest(ctx context.Context, method string, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	return req, nil
+}
+
+func (c *AccountClient) Do(req *http.Request) (*http.Response, error) {
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(req.Context(), c.AccountID); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, &NetworkError{Method: req.Method, Path: req.URL.Path, Err: err}
+	}
+
+	if c.rateLimiter != nil {
+		c.rateLimiter.Update(c.AccountID, resp.Header)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+
+		// A rejected token would otherwise be reused by every retry until it
+		// expires, drop it so the retry obtains a new one.
+		if resp.StatusCode == http.StatusUnauthorized && c.invalidate != nil {
+			c.invalidate()
+		}
+
+		return nil, newAPIError(req, resp)
+	}
+
+	return resp, nil
+}
+
+type AccountClientProvider interface {
+	ClientFor(ctx context.Context, user DocusignUser) (*AccountClient, error)
 }
 
 type AuthService struct {
-	cache     TokenCache[DocusignUser, DocusignUserCacheEntry]
-	apiClient *APIClient
-	config    *DocusignConfig
+	cache        TokenCache[DocusignUser, DocusignUserCacheEntry]
+	apiClient    *APIClient
+	config       *DocusignConfig
+	refreshes    singleflight.Group
+	refreshAhead time.Duration
 }
 
 func NewAuthService(cache TokenCache[DocusignUser, DocusignUserCacheEntry], client *APIClient, config *DocusignConfig) *AuthService {
 	return &AuthService{
-		cache:     cache,
-		apiClient: client,
-		config:    config,
+		cache:        cache,
+		apiClient:    client,
+		config:       config,
+		refreshAhead: time.Duration(config.TokenRefreshAheadSeconds) * time.Second,
 	}
 }
 
-func (s *AuthService) UpdateAuthInfo(user DocusignUser) (DocusignUserCacheEntry, error) {
-	currentAuthInfo, err := s.getAuthInfo(user)
+func (s *AuthService) ClientFor(ctx context.Context, user DocusignUser) (*AccountClient, error) {
+	authInfo, err := s.getAuthInfo(ctx, user)
 	if err != nil {
-		return DocusignUserCacheEntry{}, err
+		return nil, err
 	}
 
-	s.apiClient.BaseURL = currentAuthInfo.BaseURI
-	s.apiClient.AuthHeader.Set("Authorization", "Bearer "+currentAuthInfo.AccessToken)
+	return &AccountClient{
+		AccountID:   authInfo.AccountId,
+		BaseURL:     authInfo.BaseURI,
+		accessToken: authInfo.AccessToken,
+		client:      s.apiClient.Client,
+		rateLimiter: s.apiClient.RateLimiter,
+		invalidate: func() {
+			s.invalidate(user, authInfo.AccessToken)
+		},
+	}, nil
+}
 
-	return currentAuthInfo, nil
+// invalidate evicts the cached token of the user unless it has already been
+// replaced by a newer one.
+func (s *AuthService) invalidate(user DocusignUser, accessToken string) {
+	if cacheEntry, ok := s.cache.Get(user); ok && cacheEntry.AccessToken == accessToken {
+		s.cache.Delete(user)
+	}
 }
 
-func (s *AuthService) getAuthInfo(user DocusignUser) (DocusignUserCacheEntry, error) {
+func (s *AuthService) getAuthInfo(ctx context.Context, user DocusignUser) (DocusignUserCacheEntry, error) {
 	cacheEntry, ok := s.cache.Get(user)
 	if ok {
+		if s.refreshAhead > 0 && cacheEntry.Remaining() < s.refreshAhead {
+			s.refreshInBackground(user)
+		}
 		return cacheEntry, nil
 	}
 
+	// Concurrent misses for the same user share a single grant. The grant is
+	// detached from the caller's context so that one cancelled activity does
+	// not fail every other activity waiting on the same refresh.
+	result := s.refreshes.DoChan(user.String(), func() (interface{}, error) {
+		if cacheEntry, ok := s.cache.Get(user); ok {
+			return cacheEntry, nil
+		}
+
+		return s.fetchAuthInfo(user)
+	})
+
+	select {
+	case res := <-result:
+		if res.Err != nil {
+			return DocusignUserCacheEntry{}, res.Err
+		}
+		return res.Val.(DocusignUserCacheEntry), nil
+	case <-ctx.Done():
+		return DocusignUserCacheEntry{}, ctx.Err()
+	}
+}
+
+func (s *AuthService) refreshInBackground(user DocusignUser) {
+	s.refreshes.DoChan(user.String(), func() (interface{}, error) {
+		entry, err := s.fetchAuthInfo(user)
+		if err != nil {
+			fmt.Printf("Error refreshing token for user %s: %s\n", user, err)
+		}
+		return entry, err
+	})
+}
+
+func (s *AuthService) fetchAuthInfo(user DocusignUser) (DocusignUserCacheEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), authRefreshTimeout)
+	defer cancel()
+
 	token, err := s.makeDocusignToken(user)
 	if err != nil {
 		return DocusignUserCacheEntry{}, err
 	}
 
-	accessToken, err := s.getDocusignAccessToken(token)
+	accessToken, err := s.getDocusignAccessToken(ctx, token)
 	if err != nil {
 		return DocusignUserCacheEntry{}, err
 	}
 
-	req, err := http.NewRequest("GET", s.config.BaseAuthURL+"/oauth/userinfo", nil)
-	req.Header.Add("Authorization", "Bearer "+accessToken.Token)
+	req, err := http.NewRequestWithContext(ctx, "GET", s.config.BaseAuthURL+"/oauth/userinfo", nil)
 	if err != nil {
 		fmt.Printf("Error creating request: %s", err)
 		return DocusignUserCacheEntry{}, err
 	}
+	req.Header.Add("Authorization", "Bearer "+accessToken.Token)
 
 	resp, err := s.apiClient.Client.Do(req)
 	if err != nil {
@@ -126,22 +253,23 @@ func (s *AuthService) getAuthInfo(user DocusignUser) (DocusignUserCacheEntry, er
 		return DocusignUserCacheEntry{}, fmt.Errorf("request failed with status code: %d, body: %s", resp.StatusCode, string(body))
 	}
 
-	var accountId DocusignUserInfo
+	var userInfo DocusignUserInfo
 
-	err = json.NewDecoder(resp.Body).Decode(&accountId)
+	err = json.NewDecoder(resp.Body).Decode(&userInfo)
 	if err != nil {
 		fmt.Printf("Error decoding account ID: %s", err)
 		return DocusignUserCacheEntry{}, err
 	}
 
-	if len(accountId.Accounts) == 0 {
-		return DocusignUserCacheEntry{}, fmt.Errorf("no accounts found for user %s", user)
+	account, err := selectAccount(user, userInfo.Accounts)
+	if err != nil {
+		return DocusignUserCacheEntry{}, err
 	}
 
 	newEntry := DocusignUserCacheEntry{
 		AccessToken: accessToken.Token,
-		AccountId:   accountId.Accounts[0].AccountID,
-		BaseURI:     accountId.Accounts[0].BaseURI,
+		AccountId:   account.AccountID,
+		BaseURI:     account.BaseURI,
 		CreatedAt:   time.Now(),
 		TTL:         time.Duration(accessToken.Exp)*time.Second - DS_JWT_BUFFER,
 	}
@@ -151,24 +279,69 @@ func (s *AuthService) getAuthInfo(user DocusignUser) (DocusignUserCacheEntry, er
 	return newEntry, nil
 }
 
-func (s *AuthService) getDocusignAccessToken(jwtString string) (AccessToken, error) {
-	resp, err := http.PostForm(s.config.BaseAuthURL+"/oauth/token", url.Values{
+func selectAccount(user DocusignUser, accounts []DocusignAccountInfo) (DocusignAccountInfo, error) {
+	available := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		available = append(available, account.AccountID)
+	}
+
+	if len(accounts) == 0 {
+		return DocusignAccountInfo{}, &AccountSelectionError{UserID: user.UserID}
+	}
+
+	if user.AccountID != "" {
+		for _, account := range accounts {
+			if account.AccountID == user.AccountID {
+				return account, nil
+			}
+		}
+
+		return DocusignAccountInfo{}, &AccountSelectionError{UserID: user.UserID, AccountID: user.AccountID, Available: available}
+	}
+
+	for _, account := range accounts {
+		if account.IsDefault {
+			return account, nil
+		}
+	}
+
+	if len(accounts) == 1 {
+		return accounts[0], nil
+	}
+
+	return DocusignAccountInfo{}, &AccountSelectionError{UserID: user.UserID, Available: available}
+}
+
+func (s *AuthService) getDocusignAccessToken(ctx context.Context, jwtString string) (AccessToken, error) {
+	form := url.Values{
 		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
 		"assertion":  {jwtString},
-	})
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.config.BaseAuthURL+"/oauth/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return AccessToken{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
+	resp, err := s.apiClient.Client.Do(req)
 	if err != nil {
 		fmt.Printf("Error getting access token: %s", err)
 		return AccessToken{}, err
 	}
 
+	defer resp.Body.Close()
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		fmt.Printf("Request failed with status code: %d, body: %s", resp.StatusCode, string(body))
-		return AccessToken{}, fmt.Errorf("request failed with status code: %d, body: %s", resp.StatusCode, string(body))
-	}
+		oauthErr := parseOAuthError(resp.StatusCode, body)
+		if errors.Is(oauthErr, ErrConsentRequired) {
+			oauthErr.ConsentURL = ConsentURL(s.config)
+		}
 
-	defer resp.Body.Close()
+		fmt.Printf("Error getting access token: %s", oauthErr)
+		return AccessToken{}, oauthErr
+	}
 
 	var token AccessToken
 
@@ -184,11 +357,11 @@ func (s *AuthService) getDocusignAccessToken(jwtString string) (AccessToken, err
 func (s *AuthService) makeDocusignToken(user DocusignUser) (string, error) {
 	rawJWT := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
 		"iss":   s.config.ClientID,
-		"sub":   string(user),
+		"sub":   user.UserID,
 		"iat":   time.Now().Unix(),
 		"exp":   time.Now().Unix() + 3600,
 		"aud":   strings.TrimPrefix(s.config.BaseAuthURL, "https://"),
-		"scope": "signature impersonation",
+		"scope": strings.Join(s.config.Scopes, " "),
 	})
 
 	pem := s.config.PrivateKey
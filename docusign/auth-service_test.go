@@ -0,0 +1,317 @@
+package docusign
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+func newTestPrivateKey(t *testing.T) []byte {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+// newTestDocusignServer stubs the OAuth and eSignature endpoints. Every user
+// gets its own token, account and base URI, and envelope requests echo the
+// user the bearer token was issued to as the envelope ID. Token grants are
+// counted in tokenRequests when it is not nil.
+func newTestDocusignServer(t *testing.T, tokenRequests *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		if tokenRequests != nil {
+			tokenRequests.Add(1)
+		}
+
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		claims := jwt.MapClaims{}
+		if _, _, err := new(jwt.Parser).ParseUnverified(r.Form.Get("assertion"), claims); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		json.NewEncoder(w).Encode(AccessToken{
+			Token: "token-" + claims["sub"].(string),
+			Type:  "Bearer",
+			Exp:   3600,
+		})
+	})
+
+	mux.HandleFunc("/oauth/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		userID := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer token-")
+
+		json.NewEncoder(w).Encode(DocusignUserInfo{
+			Accounts: []DocusignAccountInfo{
+				{
+					AccountID: "account-" + userID,
+					IsDefault: true,
+					BaseURI:   server.URL + "/" + userID,
+				},
+			},
+		})
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/unauthorized") {
+			http.Error(w, `{"errorCode":"USER_AUTHENTICATION_FAILED"}`, http.StatusUnauthorized)
+			return
+		}
+
+		userID := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer token-")
+		json.NewEncoder(w).Encode(EnvelopeSummary{Status: "sent", EnvelopeID: userID})
+	})
+
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestAuthService(t *testing.T, server *httptest.Server, cache TokenCache[DocusignUser, DocusignUserCacheEntry]) *AuthService {
+	t.Helper()
+
+	config := &DocusignConfig{
+		ClientID:    "client",
+		BaseAuthURL: server.URL,
+		Scopes:      []string{"signature", "impersonation"},
+		PrivateKey:  newTestPrivateKey(t),
+	}
+
+	return NewAuthService(cache, NewAPIClient(server.Client(), nil), config)
+}
+
+func TestAuthServiceClientForDoesNotCrossUsers(t *testing.T) {
+	server := newTestDocusignServer(t, nil)
+
+	cache := NewKeysCache(time.Minute)
+	defer cache.Stop()
+
+	authService := newTestAuthService(t, server, cache)
+
+	users := []DocusignUser{{UserID: "alice"}, {UserID: "bob"}}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 200)
+
+	for i := 0; i < 100; i++ {
+		for _, user := range users {
+			wg.Add(1)
+			go func(user DocusignUser) {
+				defer wg.Done()
+
+				client, err := authService.ClientFor(context.Background(), user)
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				req, err := client.NewRequest(context.Background(), "GET", fmt.Sprintf(envelopePath, client.AccountID, "envelope"), nil)
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				if got := req.Header.Get("Authorization"); got != "Bearer token-"+user.UserID {
+					errs <- fmt.Errorf("%s sent authorization %q", user.UserID, got)
+				}
+
+				wantPath := "/" + user.UserID + fmt.Sprintf(envelopePath, "account-"+user.UserID, "envelope")
+				if req.URL.Path != wantPath {
+					errs <- fmt.Errorf("%s requested %s, want %s", user.UserID, req.URL.Path, wantPath)
+				}
+
+				resp, err := client.Do(req)
+				if err != nil {
+					errs <- err
+					return
+				}
+				defer resp.Body.Close()
+
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				var summary EnvelopeSummary
+				if err := json.Unmarshal(body, &summary); err != nil {
+					errs <- err
+					return
+				}
+
+				if summary.EnvelopeID != user.UserID {
+					errs <- fmt.Errorf("%s was served the token of %s", user.UserID, summary.EnvelopeID)
+				}
+			}(user)
+		}
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestAccountClientEvictsTokenOnUnauthorized(t *testing.T) {
+	server := newTestDocusignServer(t, nil)
+
+	cache := NewKeysCache(time.Minute)
+	defer cache.Stop()
+
+	authService := newTestAuthService(t, server, cache)
+	user := DocusignUser{UserID: "alice"}
+
+	client, err := authService.ClientFor(context.Background(), user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := cache.Get(user); !ok {
+		t.Fatal("expected the token to be cached")
+	}
+
+	req, err := client.NewRequest(context.Background(), "GET", fmt.Sprintf(envelopePath, client.AccountID, "unauthorized"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var apiErr *APIError
+	if _, err := client.Do(req); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected an unauthorized API error, got %v", err)
+	}
+
+	if _, ok := cache.Get(user); ok {
+		t.Fatal("expected the rejected token to be evicted")
+	}
+}
+
+func TestAuthServiceClientForSharesOneGrantInBursts(t *testing.T) {
+	var tokenRequests atomic.Int32
+	server := newTestDocusignServer(t, &tokenRequests)
+
+	cache := NewKeysCache(time.Minute)
+	defer cache.Stop()
+
+	authService := newTestAuthService(t, server, cache)
+	user := DocusignUser{UserID: "alice"}
+
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			client, err := authService.ClientFor(context.Background(), user)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			if client.accessToken != "token-alice" {
+				errs <- fmt.Errorf("unexpected access token %q", client.accessToken)
+			}
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if got := tokenRequests.Load(); got != 1 {
+		t.Fatalf("expected one token grant for the burst, got %d", got)
+	}
+}
+
+func TestAuthServiceRefreshesTokenAheadOfExpiry(t *testing.T) {
+	var tokenRequests atomic.Int32
+	server := newTestDocusignServer(t, &tokenRequests)
+
+	cache := NewKeysCache(time.Minute)
+	defer cache.Stop()
+
+	authService := newTestAuthService(t, server, cache)
+	authService.refreshAhead = 10 * time.Minute
+
+	user := DocusignUser{UserID: "alice"}
+	cache.Set(user, DocusignUserCacheEntry{
+		AccessToken: "expiring-token",
+		AccountId:   "account-alice",
+		BaseURI:     server.URL + "/alice",
+		CreatedAt:   time.Now(),
+		TTL:         time.Minute,
+	})
+
+	client, err := authService.ClientFor(context.Background(), user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if client.accessToken != "expiring-token" {
+		t.Fatalf("expected the cached token to be used while refreshing, got %q", client.accessToken)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if entry, ok := cache.Get(user); ok && entry.AccessToken == "token-alice" {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("expected the token to be refreshed in the background")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for i := 0; i < 10; i++ {
+		client, err := authService.ClientFor(context.Background(), user)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if client.accessToken != "token-alice" {
+			t.Fatalf("expected the refreshed token, got %q", client.accessToken)
+		}
+	}
+
+	if got := tokenRequests.Load(); got != 1 {
+		t.Fatalf("expected a single background grant, got %d", got)
+	}
+}
@@ -8,6 +8,7 @@ import (
 type TokenCache[K comparable, V any] interface {
 	Get(key K) (V, bool)
 	Set(key K, value V)
+	Delete(key K)
 	Start()
 	Stop()
 }
@@ -37,7 +38,11 @@ func (c *KeysCache) Get(user DocusignUser) (DocusignUserCacheEntry, bool) {
 	defer c.mu.RUnlock()
 
 	entry, ok := c.entries[user]
-	return entry, ok
+	if !ok || entry.Expired() {
+		return DocusignUserCacheEntry{}, false
+	}
+
+	return entry, true
 }
 
 func (c *KeysCache) Set(user DocusignUser, entry DocusignUserCacheEntry) {
@@ -47,6 +52,13 @@ func (c *KeysCache) Set(user DocusignUser, entry DocusignUserCacheEntry) {
 	c.entries[user] = entry
 }
 
+func (c *KeysCache) Delete(user DocusignUser) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, user)
+}
+
 func (c *KeysCache) Start() {
 	ticker := time.NewTicker(c.interval)
 
@@ -57,7 +69,7 @@ func (c *KeysCache) Start() {
 			case <-ticker.C:
 				c.mu.Lock()
 				for k, v := range c.entries {
-					if time.Since(v.CreatedAt) > v.TTL {
+					if v.Expired() {
 						delete(c.entries, k)
 					}
 				}
@@ -1,31 +1,317 @@
 package docusign
 
 import (
-	"log"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ProductionAuthURL = "https://account.docusign.com"
+	DemoAuthURL       = "https://account-d.docusign.com"
 )
 
 type DocusignConfig struct {
-	ClientID    string
-	BaseAuthURL string
-	PrivateKey  []byte
+	Environment              string   `json:"environment" yaml:"environment"`
+	ClientID                 string   `json:"clientId" yaml:"clientId"`
+	BaseAuthURL              string   `json:"baseAuthUrl" yaml:"baseAuthUrl"`
+	PrivateKeyData           string   `json:"privateKey" yaml:"privateKey"`
+	PrivateKeyFile           string   `json:"privateKeyFile" yaml:"privateKeyFile"`
+	TaskQueue                string   `json:"taskQueue" yaml:"taskQueue"`
+	TemporalHost             string   `json:"temporalHost" yaml:"temporalHost"`
+	TemporalNamespace        string   `json:"temporalNamespace" yaml:"temporalNamespace"`
+	ConnectHMACKeys          []string `json:"connectHmacKeys" yaml:"connectHmacKeys"`
+	ConnectListenAddr        string   `json:"connectListenAddr" yaml:"connectListenAddr"`
+	Scopes                   []string `json:"scopes" yaml:"scopes"`
+	ConsentRedirectURI       string   `json:"consentRedirectUri" yaml:"consentRedirectUri"`
+	RateLimitThreshold       int      `json:"rateLimitThreshold" yaml:"rateLimitThreshold"`
+	TokenCacheBackend        string   `json:"tokenCacheBackend" yaml:"tokenCacheBackend"`
+	TokenCacheFile           string   `json:"tokenCacheFile" yaml:"tokenCacheFile"`
+	TokenCacheEncryptionKey  string   `json:"tokenCacheEncryptionKey" yaml:"tokenCacheEncryptionKey"`
+	TokenRefreshAheadSeconds int      `json:"tokenRefreshAheadSeconds" yaml:"tokenRefreshAheadSeconds"`
+	ArchiveDir               string   `json:"archiveDir" yaml:"archiveDir"`
+	DocumentDir              string   `json:"documentDir" yaml:"documentDir"`
+
+	PrivateKey []byte `json:"-" yaml:"-"`
 }
 
-func LoadConfig() *DocusignConfig {
-	privateKey, err := os.ReadFile("../private.pem")
+// DefaultDotEnvFile is the .env file the commands read by default, relative to
+// the command's directory. DOCUSIGN_ENV_FILE points to another one.
+const DefaultDotEnvFile = "../.env"
+
+type ConfigSource func(cfg *DocusignConfig) error
+
+// DefaultConfigSources loads the optional .env file first so every command
+// sees the same variables, then the file named by DOCUSIGN_CONFIG_FILE and
+// finally the environment.
+func DefaultConfigSources() []ConfigSource {
+	dotEnvFile := DefaultDotEnvFile
+	if path := os.Getenv("DOCUSIGN_ENV_FILE"); path != "" {
+		dotEnvFile = path
+	}
+
+	return []ConfigSource{FromDotEnv(dotEnvFile), fromConfigFileEnv(), FromEnv()}
+}
+
+// FromDotEnv adds the variables of a .env file to the environment without
+// overriding variables that are already set. A missing file is not an error,
+// containers usually pass the configuration as plain environment variables.
+func FromDotEnv(path string) ConfigSource {
+	return func(cfg *DocusignConfig) error {
+		err := godotenv.Load(path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("loading %s: %w", path, err)
+		}
+
+		return nil
+	}
+}
+
+func fromConfigFileEnv() ConfigSource {
+	return func(cfg *DocusignConfig) error {
+		if path := os.Getenv("DOCUSIGN_CONFIG_FILE"); path != "" {
+			return FromFile(path)(cfg)
+		}
+
+		return nil
+	}
+}
+
+func FromFile(path string) ConfigSource {
+	return func(cfg *DocusignConfig) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading config file: %w", err)
+		}
+
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json":
+			err = json.Unmarshal(data, cfg)
+		case ".yaml", ".yml":
+			err = yaml.Unmarshal(data, cfg)
+		default:
+			return fmt.Errorf("unsupported config file format: %s", path)
+		}
+
+		if err != nil {
+			return fmt.Errorf("parsing config file %s: %w", path, err)
+		}
+
+		return nil
+	}
+}
+
+func FromEnv() ConfigSource {
+	return func(cfg *DocusignConfig) error {
+		setFromEnv(&cfg.Environment, "DOCUSIGN_ENVIRONMENT")
+		if os.Getenv("APP_ENV") == "dev" && cfg.Environment == "" {
+			cfg.Environment = "demo"
+		}
+
+		setFromEnv(&cfg.ClientID, "DOCUSIGN_CLIENT_ID")
+		setFromEnv(&cfg.BaseAuthURL, "DOCUSIGN_BASE_AUTH_URL")
+		setFromEnv(&cfg.PrivateKeyData, "DOCUSIGN_PRIVATE_KEY")
+		setFromEnv(&cfg.PrivateKeyFile, "DOCUSIGN_PRIVATE_KEY_FILE")
+		setFromEnv(&cfg.TaskQueue, "TEMPORAL_TASK_QUEUE")
+		setFromEnv(&cfg.TemporalHost, "TEMPORAL_HOST")
+		setFromEnv(&cfg.TemporalNamespace, "TEMPORAL_NAMESPACE")
+		setFromEnv(&cfg.ConnectListenAddr, "CONNECT_LISTEN_ADDR")
+		setFromEnv(&cfg.ConsentRedirectURI, "DOCUSIGN_CONSENT_REDIRECT_URI")
+		setFromEnv(&cfg.ArchiveDir, "DOCUSIGN_ARCHIVE_DIR")
+		setFromEnv(&cfg.DocumentDir, "DOCUSIGN_DOCUMENT_DIR")
+		setFromEnv(&cfg.TokenCacheBackend, "DOCUSIGN_TOKEN_CACHE_BACKEND")
+		setFromEnv(&cfg.TokenCacheFile, "DOCUSIGN_TOKEN_CACHE_FILE")
+		setFromEnv(&cfg.TokenCacheEncryptionKey, "DOCUSIGN_TOKEN_CACHE_ENCRYPTION_KEY")
+
+		if threshold := os.Getenv("DOCUSIGN_RATE_LIMIT_THRESHOLD"); threshold != "" {
+			value, err := strconv.Atoi(threshold)
+			if err != nil {
+				return fmt.Errorf("invalid DOCUSIGN_RATE_LIMIT_THRESHOLD: %w", err)
+			}
+			cfg.RateLimitThreshold = value
+		}
+
+		if refreshAhead := os.Getenv("DOCUSIGN_TOKEN_REFRESH_AHEAD_SECONDS"); refreshAhead != "" {
+			value, err := strconv.Atoi(refreshAhead)
+			if err != nil {
+				return fmt.Errorf("invalid DOCUSIGN_TOKEN_REFRESH_AHEAD_SECONDS: %w", err)
+			}
+			cfg.TokenRefreshAheadSeconds = value
+		}
+
+		if scopes := os.Getenv("DOCUSIGN_SCOPES"); scopes != "" {
+			cfg.Scopes = strings.Fields(scopes)
+		}
+
+		if keys := os.Getenv("DOCUSIGN_CONNECT_HMAC_KEYS"); keys != "" {
+			cfg.ConnectHMACKeys = nil
+			for _, key := range strings.Split(keys, ",") {
+				if key = strings.TrimSpace(key); key != "" {
+					cfg.ConnectHMACKeys = append(cfg.ConnectHMACKeys, key)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+func WithPrivateKey(key string) ConfigSource {
+	return func(cfg *DocusignConfig) error {
+		cfg.PrivateKeyData = key
+		return nil
+	}
+}
+
+func setFromEnv(field *string, name string) {
+	if value, ok := os.LookupEnv(name); ok && value != "" {
+		*field = value
+	}
+}
+
+// ReadConfig applies the sources on top of the defaults without validating
+// the DocuSign credentials, so commands that only talk to Temporal can share it.
+func ReadConfig(sources ...ConfigSource) (*DocusignConfig, error) {
+	if len(sources) == 0 {
+		sources = DefaultConfigSources()
+	}
+
+	cfg := &DocusignConfig{
+		Environment:        "production",
+		TaskQueue:          "nda",
+		TemporalHost:       "localhost:7233",
+		TemporalNamespace:  "default",
+		ConnectListenAddr:  ":8080",
+		Scopes:             []string{"signature", "impersonation"},
+		RateLimitThreshold: DefaultRateLimitThreshold,
+		TokenCacheBackend:  MemoryTokenCacheBackend,
+		ArchiveDir:         "archive",
+		DocumentDir:        "documents",
+	}
+
+	for _, source := range sources {
+		if err := source(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.BaseAuthURL == "" {
+		switch cfg.Environment {
+		case "production":
+			cfg.BaseAuthURL = ProductionAuthURL
+		case "demo", "dev":
+			cfg.BaseAuthURL = DemoAuthURL
+		default:
+			return nil, fmt.Errorf("unknown DocuSign environment: %s", cfg.Environment)
+		}
+	}
+
+	privateKey, err := cfg.resolvePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	cfg.PrivateKey = privateKey
+
+	return cfg, nil
+}
+
+func LoadConfig(sources ...ConfigSource) (*DocusignConfig, error) {
+	cfg, err := ReadConfig(sources...)
 	if err != nil {
-		log.Fatalf("Error reading private key: %v", err)
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (c *DocusignConfig) Validate() error {
+	var problems []error
+
+	if c.ClientID == "" {
+		problems = append(problems, errors.New("client ID is required"))
+	}
+
+	if len(c.PrivateKey) == 0 {
+		problems = append(problems, errors.New("private key is required"))
+	} else if _, err := jwt.ParseRSAPrivateKeyFromPEM(c.PrivateKey); err != nil {
+		problems = append(problems, fmt.Errorf("private key is not a valid RSA key: %w", err))
 	}
 
-	baseAuthURL := "https://account.docusign.com"
+	if c.ConsentRedirectURI != "" {
+		if _, err := url.ParseRequestURI(c.ConsentRedirectURI); err != nil {
+			problems = append(problems, fmt.Errorf("consent redirect URI is invalid: %w", err))
+		}
+	}
+
+	switch c.TokenCacheBackend {
+	case MemoryTokenCacheBackend:
+	case FileTokenCacheBackend:
+		if c.TokenCacheFile == "" || c.TokenCacheEncryptionKey == "" {
+			problems = append(problems, errors.New("file token cache requires a file path and an encryption key"))
+		}
+	default:
+		problems = append(problems, fmt.Errorf("unknown token cache backend: %s", c.TokenCacheBackend))
+	}
+
+	if c.TaskQueue == "" {
+		problems = append(problems, errors.New("task queue is required"))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid DocuSign config: %w", errors.Join(problems...))
+	}
+
+	return nil
+}
+
+func (c *DocusignConfig) resolvePrivateKey() ([]byte, error) {
+	if c.PrivateKeyData != "" {
+		return decodePrivateKey(c.PrivateKeyData)
+	}
+
+	if c.PrivateKeyFile != "" {
+		data, err := os.ReadFile(c.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading private key: %w", err)
+		}
+
+		return data, nil
+	}
+
+	return c.PrivateKey, nil
+}
 
-	if os.Getenv("APP_ENV") == "dev" {
-		baseAuthURL = "https://account-d.docusign.com"
+func decodePrivateKey(data string) ([]byte, error) {
+	data = strings.TrimSpace(data)
+	if strings.HasPrefix(data, "-----BEGIN") {
+		return []byte(data), nil
 	}
 
-	return &DocusignConfig{
-		ClientID:    os.Getenv("DOCUSIGN_CLIENT_ID"),
-		BaseAuthURL: baseAuthURL,
-		PrivateKey:  privateKey,
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("private key is neither PEM nor base64: %w", err)
 	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(decoded), []byte("-----BEGIN")) {
+		return decoded, nil
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: decoded}), nil
 }
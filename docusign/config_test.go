@@ -0,0 +1,160 @@
+package docusign
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestFromFileReadsJSONAndYAML(t *testing.T) {
+	files := map[string]string{
+		"config.json": `{"clientId": "client", "taskQueue": "contracts", "connectHmacKeys": ["a", "b"]}`,
+		"config.yaml": "clientId: client\ntaskQueue: contracts\nconnectHmacKeys:\n  - a\n  - b\n",
+	}
+
+	for name, content := range files {
+		cfg, err := ReadConfig(FromFile(writeConfigFile(t, name, content)))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		if cfg.ClientID != "client" || cfg.TaskQueue != "contracts" || strings.Join(cfg.ConnectHMACKeys, ",") != "a,b" {
+			t.Fatalf("%s: unexpected config %+v", name, cfg)
+		}
+
+		if cfg.TemporalHost != "localhost:7233" || cfg.BaseAuthURL != ProductionAuthURL {
+			t.Fatalf("%s: expected the defaults to be kept, got %+v", name, cfg)
+		}
+	}
+}
+
+func TestFromFileRejectsUnknownFormats(t *testing.T) {
+	if _, err := ReadConfig(FromFile(writeConfigFile(t, "config.toml", "clientId = 'client'"))); err == nil {
+		t.Fatal("expected an unsupported format to be rejected")
+	}
+}
+
+func TestFromEnvOverridesFile(t *testing.T) {
+	t.Setenv("DOCUSIGN_CLIENT_ID", "env-client")
+	t.Setenv("DOCUSIGN_ENVIRONMENT", "demo")
+	t.Setenv("TEMPORAL_TASK_QUEUE", "env-queue")
+	t.Setenv("DOCUSIGN_RATE_LIMIT_THRESHOLD", "42")
+	t.Setenv("DOCUSIGN_SCOPES", "signature extended")
+	t.Setenv("DOCUSIGN_CONNECT_HMAC_KEYS", " old , new ")
+
+	file := FromFile(writeConfigFile(t, "config.json", `{"clientId": "file-client", "taskQueue": "file-queue"}`))
+
+	cfg, err := ReadConfig(file, FromEnv())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.ClientID != "env-client" || cfg.TaskQueue != "env-queue" || cfg.RateLimitThreshold != 42 {
+		t.Fatalf("expected the environment to win, got %+v", cfg)
+	}
+
+	if cfg.BaseAuthURL != DemoAuthURL {
+		t.Fatalf("expected the demo auth URL, got %s", cfg.BaseAuthURL)
+	}
+
+	if strings.Join(cfg.Scopes, ",") != "signature,extended" || strings.Join(cfg.ConnectHMACKeys, ",") != "old,new" {
+		t.Fatalf("unexpected scopes or keys: %v %v", cfg.Scopes, cfg.ConnectHMACKeys)
+	}
+}
+
+func TestFromEnvRejectsInvalidNumbers(t *testing.T) {
+	t.Setenv("DOCUSIGN_RATE_LIMIT_THRESHOLD", "many")
+
+	if _, err := ReadConfig(FromEnv()); err == nil {
+		t.Fatal("expected an invalid threshold to be rejected")
+	}
+}
+
+func TestFromDotEnvIsOptional(t *testing.T) {
+	if _, err := ReadConfig(FromDotEnv(filepath.Join(t.TempDir(), ".env"))); err != nil {
+		t.Fatalf("expected a missing .env file to be ignored, got %v", err)
+	}
+
+	t.Setenv("TEMPORAL_TASK_QUEUE", "")
+	os.Unsetenv("TEMPORAL_TASK_QUEUE")
+
+	path := writeConfigFile(t, ".env", "TEMPORAL_TASK_QUEUE=dotenv-queue\n")
+
+	cfg, err := ReadConfig(FromDotEnv(path), FromEnv())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.TaskQueue != "dotenv-queue" {
+		t.Fatalf("expected the task queue from the .env file, got %s", cfg.TaskQueue)
+	}
+}
+
+func TestInlinePrivateKeyDecoding(t *testing.T) {
+	key := newTestPrivateKey(t)
+
+	block, _ := pem.Decode(key)
+	parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inputs := map[string]string{
+		"pem":        string(key),
+		"base64 pem": base64.StdEncoding.EncodeToString(key),
+		"base64 der": base64.StdEncoding.EncodeToString(pkcs8),
+	}
+
+	for name, input := range inputs {
+		cfg, err := LoadConfig(WithPrivateKey(input), func(cfg *DocusignConfig) error {
+			cfg.ClientID = "client"
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		block, _ := pem.Decode(cfg.PrivateKey)
+		if block == nil {
+			t.Fatalf("%s: expected a PEM encoded key", name)
+		}
+
+		var decoded interface{}
+		if block.Type == "RSA PRIVATE KEY" {
+			decoded, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		} else {
+			decoded, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		if !parsed.Equal(decoded.(*rsa.PrivateKey)) {
+			t.Fatalf("%s: decoded a different key", name)
+		}
+	}
+
+	if _, err := ReadConfig(WithPrivateKey("not a key!")); err == nil {
+		t.Fatal("expected a key that is neither PEM nor base64 to be rejected")
+	}
+}
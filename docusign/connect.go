@@ -0,0 +1,174 @@
+package docusign
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.temporal.io/api/serviceerror"
+)
+
+const EnvelopeEventSignal = "envelope-event"
+
+const connectSignatureHeaderPrefix = "X-Docusign-Signature-"
+const connectMaxBodySize = 1 << 20
+
+type ConnectEvent struct {
+	Event             string           `json:"event"`
+	APIVersion        string           `json:"apiVersion"`
+	URI               string           `json:"uri"`
+	RetryCount        int              `json:"retryCount"`
+	ConfigurationID   json.Number      `json:"configurationId"`
+	GeneratedDateTime time.Time        `json:"generatedDateTime"`
+	Data              ConnectEventData `json:"data"`
+}
+
+type ConnectEventData struct {
+	AccountID       string          `json:"accountId"`
+	UserID          string          `json:"userId"`
+	EnvelopeID      string          `json:"envelopeId"`
+	EnvelopeSummary *EnvelopeStatus `json:"envelopeSummary,omitempty"`
+}
+
+// connectEventStatuses maps the Connect events that announce an envelope
+// status. Other envelope events such as envelope-resent or envelope-corrected
+// carry no status of their own.
+var connectEventStatuses = map[string]string{
+	"envelope-sent":      "sent",
+	"envelope-delivered": "delivered",
+	"envelope-completed": "completed",
+	"envelope-declined":  "declined",
+	"envelope-voided":    "voided",
+}
+
+type EnvelopeEvent struct {
+	Event       string    `json:"event"`
+	EnvelopeID  string    `json:"envelopeId"`
+	Status      string    `json:"status"`
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+func (e ConnectEvent) EnvelopeEvent() EnvelopeEvent {
+	status := ""
+	if e.Data.EnvelopeSummary != nil {
+		status = e.Data.EnvelopeSummary.Status
+	}
+
+	if status == "" {
+		status = connectEventStatuses[e.Event]
+	}
+
+	return EnvelopeEvent{
+		Event:       e.Event,
+		EnvelopeID:  e.Data.EnvelopeID,
+		Status:      status,
+		GeneratedAt: e.GeneratedDateTime,
+	}
+}
+
+func WaitForSigningWorkflowID(envelopeID string) string {
+	return "wait-for-signing-" + envelopeID
+}
+
+type WorkflowSignaler interface {
+	SignalWorkflow(ctx context.Context, workflowID string, runID string, signalName string, arg interface{}) error
+}
+
+type ConnectHandler struct {
+	hmacKeys [][]byte
+	signaler WorkflowSignaler
+}
+
+func NewConnectHandler(hmacKeys [][]byte, signaler WorkflowSignaler) *ConnectHandler {
+	return &ConnectHandler{
+		hmacKeys: hmacKeys,
+		signaler: signaler,
+	}
+}
+
+func (h *ConnectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, connectMaxBodySize))
+	if err != nil {
+		http.Error(w, "unable to read body", http.StatusBadRequest)
+		return
+	}
+
+	if !h.verifySignature(r.Header, body) {
+		fmt.Printf("Rejected Connect event with invalid signature\n")
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var event ConnectEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, "invalid event payload", http.StatusBadRequest)
+		return
+	}
+
+	if event.Data.EnvelopeID == "" {
+		fmt.Printf("Ignoring Connect event %s without envelope ID\n", event.Event)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	envelopeEvent := event.EnvelopeEvent()
+	err = h.signaler.SignalWorkflow(r.Context(), WaitForSigningWorkflowID(envelopeEvent.EnvelopeID), "", EnvelopeEventSignal, envelopeEvent)
+
+	var notFound *serviceerror.NotFound
+	if errors.As(err, &notFound) {
+		fmt.Printf("No workflow waiting for envelope %s, ignoring %s event\n", envelopeEvent.EnvelopeID, event.Event)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err != nil {
+		fmt.Printf("Error signaling workflow for envelope %s: %s\n", envelopeEvent.EnvelopeID, err)
+		http.Error(w, "unable to deliver event", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *ConnectHandler) verifySignature(header http.Header, body []byte) bool {
+	var signatures [][]byte
+	for name, values := range header {
+		if !strings.HasPrefix(http.CanonicalHeaderKey(name), connectSignatureHeaderPrefix) {
+			continue
+		}
+
+		for _, value := range values {
+			signature, err := base64.StdEncoding.DecodeString(value)
+			if err == nil {
+				signatures = append(signatures, signature)
+			}
+		}
+	}
+
+	for _, key := range h.hmacKeys {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(body)
+		expected := mac.Sum(nil)
+
+		for _, signature := range signatures {
+			if hmac.Equal(expected, signature) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
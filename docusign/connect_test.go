@@ -0,0 +1,103 @@
+package docusign
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type recordingSignaler struct {
+	workflowIDs []string
+	events      []EnvelopeEvent
+}
+
+func (s *recordingSignaler) SignalWorkflow(ctx context.Context, workflowID string, runID string, signalName string, arg interface{}) error {
+	s.workflowIDs = append(s.workflowIDs, workflowID)
+	s.events = append(s.events, arg.(EnvelopeEvent))
+	return nil
+}
+
+func connectSignature(key string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestConnectHandlerVerifiesSignature(t *testing.T) {
+	body := []byte(`{"event":"envelope-completed","data":{"envelopeId":"envelope"}}`)
+
+	tests := []struct {
+		name       string
+		keys       []string
+		signatures []string
+		expected   int
+	}{
+		{"valid signature", []string{"current"}, []string{connectSignature("current", body)}, http.StatusOK},
+		{"wrong key", []string{"current"}, []string{connectSignature("other", body)}, http.StatusUnauthorized},
+		{"missing signature", []string{"current"}, nil, http.StatusUnauthorized},
+		{"rotated key in second header", []string{"new"}, []string{connectSignature("old", body), connectSignature("new", body)}, http.StatusOK},
+		{"old key still accepted", []string{"new", "old"}, []string{connectSignature("old", body)}, http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keys := make([][]byte, 0, len(test.keys))
+			for _, key := range test.keys {
+				keys = append(keys, []byte(key))
+			}
+
+			signaler := &recordingSignaler{}
+			handler := NewConnectHandler(keys, signaler)
+
+			req := httptest.NewRequest(http.MethodPost, "/docusign/connect", bytes.NewReader(body))
+			for i, signature := range test.signatures {
+				req.Header.Set(connectSignatureHeaderPrefix+string(rune('1'+i)), signature)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != test.expected {
+				t.Fatalf("expected status %d, got %d", test.expected, rec.Code)
+			}
+
+			signaled := len(signaler.events) == 1
+			if signaled != (test.expected == http.StatusOK) {
+				t.Fatalf("expected the workflow to be signaled only for valid signatures, got %d signals", len(signaler.events))
+			}
+
+			if signaled && (signaler.workflowIDs[0] != WaitForSigningWorkflowID("envelope") || signaler.events[0].Status != "completed") {
+				t.Fatalf("unexpected signal %s %+v", signaler.workflowIDs[0], signaler.events[0])
+			}
+		})
+	}
+}
+
+func TestEnvelopeEventOnlyMapsStatusEvents(t *testing.T) {
+	events := map[string]string{
+		"envelope-sent":      "sent",
+		"envelope-delivered": "delivered",
+		"envelope-completed": "completed",
+		"envelope-declined":  "declined",
+		"envelope-voided":    "voided",
+		"envelope-resent":    "",
+		"envelope-corrected": "",
+		"envelope-purge":     "",
+	}
+
+	for name, status := range events {
+		if got := (ConnectEvent{Event: name}).EnvelopeEvent().Status; got != status {
+			t.Errorf("%s: expected status %q, got %q", name, status, got)
+		}
+	}
+
+	event := ConnectEvent{Event: "envelope-resent", Data: ConnectEventData{EnvelopeSummary: &EnvelopeStatus{Status: "sent"}}}
+	if got := event.EnvelopeEvent().Status; got != "sent" {
+		t.Fatalf("expected the envelope summary status, got %q", got)
+	}
+}
@@ -0,0 +1,153 @@
+package docusign
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"time"
+)
+
+const CorrectRecipientUpdate = "correct-recipient"
+
+type RecipientCorrection struct {
+	RecipientID string `json:"recipientId"`
+	Name        string `json:"name,omitempty"`
+	Email       string `json:"email,omitempty"`
+}
+
+func (c RecipientCorrection) Validate() error {
+	if c.RecipientID == "" {
+		return newValidationError("a recipient ID is required")
+	}
+
+	if c.Name == "" && c.Email == "" {
+		return newValidationError("a corrected name or email is required")
+	}
+
+	if c.Email != "" {
+		if _, err := mail.ParseAddress(c.Email); err != nil {
+			return newValidationError("invalid email %q: %v", c.Email, err)
+		}
+	}
+
+	return nil
+}
+
+// AppliedCorrection records a correction together with the values it replaced.
+type AppliedCorrection struct {
+	RecipientCorrection
+	PreviousName  string    `json:"previousName"`
+	PreviousEmail string    `json:"previousEmail"`
+	CorrectedAt   time.Time `json:"correctedAt"`
+}
+
+type RecipientUpdate struct {
+	RecipientID string `json:"recipientId"`
+	Name        string `json:"name,omitempty"`
+	Email       string `json:"email,omitempty"`
+	SignerName  string `json:"signerName,omitempty"`
+	SignerEmail string `json:"signerEmail,omitempty"`
+}
+
+type EnvelopeRecipientsUpdate struct {
+	Signers             []RecipientUpdate `json:"signers,omitempty"`
+	InPersonSigners     []RecipientUpdate `json:"inPersonSigners,omitempty"`
+	CarbonCopies        []RecipientUpdate `json:"carbonCopies,omitempty"`
+	CertifiedDeliveries []RecipientUpdate `json:"certifiedDeliveries,omitempty"`
+}
+
+type RecipientUpdateResults struct {
+	Results []RecipientUpdateResult `json:"recipientUpdateResults"`
+}
+
+type RecipientUpdateResult struct {
+	RecipientID  string `json:"recipientId"`
+	ErrorDetails *struct {
+		ErrorCode string `json:"errorCode"`
+		Message   string `json:"message"`
+	} `json:"errorDetails,omitempty"`
+}
+
+func newRecipientsUpdate(recipientType string, correction RecipientCorrection) (EnvelopeRecipientsUpdate, error) {
+	update := RecipientUpdate{
+		RecipientID: correction.RecipientID,
+		Name:        correction.Name,
+		Email:       correction.Email,
+	}
+
+	switch recipientType {
+	case SignerRecipientType:
+		return EnvelopeRecipientsUpdate{Signers: []RecipientUpdate{update}}, nil
+	case CarbonCopyRecipientType:
+		return EnvelopeRecipientsUpdate{CarbonCopies: []RecipientUpdate{update}}, nil
+	case CertifiedDeliveryRecipientType:
+		return EnvelopeRecipientsUpdate{CertifiedDeliveries: []RecipientUpdate{update}}, nil
+	case InPersonSignerRecipientType:
+		update = RecipientUpdate{
+			RecipientID: correction.RecipientID,
+			SignerName:  correction.Name,
+			SignerEmail: correction.Email,
+		}
+		return EnvelopeRecipientsUpdate{InPersonSigners: []RecipientUpdate{update}}, nil
+	default:
+		return EnvelopeRecipientsUpdate{}, newValidationError("recipients of type %q cannot be corrected", recipientType)
+	}
+}
+
+// CorrectRecipient changes the name or email of a recipient of a sent envelope
+// and resends the envelope so the corrected recipient is notified.
+func (s *Activities) CorrectRecipient(ctx context.Context, envelopeID string, recipientType string, correction RecipientCorrection, user DocusignUser) error {
+	if err := correction.Validate(); err != nil {
+		return err
+	}
+
+	update, err := newRecipientsUpdate(recipientType, correction)
+	if err != nil {
+		return err
+	}
+
+	client, err := s.clientFor(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	updateJSON, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	req, err := client.NewRequest(
+		ctx,
+		"PUT",
+		fmt.Sprintf(envelopeRecipientsPath, client.AccountID, envelopeID)+"?resend_envelope=true",
+		bytes.NewBuffer(updateJSON),
+	)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(client, req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	var results RecipientUpdateResults
+	err = json.NewDecoder(resp.Body).Decode(&results)
+	if err != nil {
+		return err
+	}
+
+	// DocuSign reports rejected recipient updates in the body of a successful
+	// response.
+	for _, result := range results.Results {
+		if result.ErrorDetails != nil && result.ErrorDetails.ErrorCode != "" && result.ErrorDetails.ErrorCode != "SUCCESS" {
+			return newValidationError("correcting recipient %s failed: %s %s", result.RecipientID, result.ErrorDetails.ErrorCode, result.ErrorDetails.Message)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,322 @@
+package docusign
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var supportedDocumentExtensions = map[string]bool{
+	"pdf":  true,
+	"docx": true,
+	"doc":  true,
+}
+
+// MaxInlineDocumentSize caps documents passed as base64 content. The content
+// travels through workflow and activity inputs and is recorded in the workflow
+// history, larger documents have to be passed by path.
+const MaxInlineDocumentSize = 256 * 1024
+
+type CustomDocument struct {
+	DocumentID    string `json:"documentId"`
+	Name          string `json:"name"`
+	Path          string `json:"path,omitempty"`
+	ContentBase64 string `json:"contentBase64,omitempty"`
+}
+
+type CustomEnvelopeInput struct {
+	User                DocusignUser             `json:"user"`
+	EmailSubject        string                   `json:"emailSubject"`
+	Status              string                   `json:"status"`
+	Documents           []CustomDocument         `json:"documents"`
+	Signers             []DocusignRecipient      `json:"signers"`
+	InPersonSigners     []DocusignInPersonSigner `json:"inPersonSigners"`
+	CarbonCopies        []DocusignRecipient      `json:"carbonCopies"`
+	CertifiedDeliveries []DocusignRecipient      `json:"certifiedDeliveries"`
+	SigningSchedule     SigningSchedule          `json:"signingSchedule"`
+}
+
+func (s *Activities) CreateCustomEnvelope(ctx context.Context, input CustomEnvelopeInput) (EnvelopeSummary, error) {
+	definition, err := buildEnvelopeDefinition(input, s.documentRoot)
+	if err != nil {
+		return EnvelopeSummary{}, err
+	}
+
+	client, err := s.clientFor(ctx, input.User)
+	if err != nil {
+		return EnvelopeSummary{}, err
+	}
+
+	definitionJSON, err := json.Marshal(definition)
+	if err != nil {
+		return EnvelopeSummary{}, err
+	}
+
+	req, err := client.NewRequest(
+		ctx,
+		"POST",
+		fmt.Sprintf(envelopesPath, client.AccountID),
+		bytes.NewBuffer(definitionJSON),
+	)
+	if err != nil {
+		return EnvelopeSummary{}, err
+	}
+
+	resp, err := s.do(client, req)
+	if err != nil {
+		return EnvelopeSummary{}, err
+	}
+
+	defer resp.Body.Close()
+
+	var envelopeSummary EnvelopeSummary
+	err = json.NewDecoder(resp.Body).Decode(&envelopeSummary)
+	if err != nil {
+		return EnvelopeSummary{}, err
+	}
+
+	return envelopeSummary, nil
+}
+
+func buildEnvelopeDefinition(input CustomEnvelopeInput, documentRoot string) (EnvelopeDefinition, error) {
+	if len(input.Documents) == 0 {
+		return EnvelopeDefinition{}, newValidationError("at least one document is required")
+	}
+
+	if len(input.Signers) == 0 && len(input.InPersonSigners) == 0 {
+		return EnvelopeDefinition{}, newValidationError("at least one signer is required")
+	}
+
+	if err := input.SigningSchedule.Validate(); err != nil {
+		return EnvelopeDefinition{}, err
+	}
+
+	status := input.Status
+	if status == "" {
+		status = "created"
+	}
+
+	if status != "created" && status != "sent" {
+		return EnvelopeDefinition{}, newValidationError("envelope status must be created or sent, got %s", status)
+	}
+
+	explicitDocumentIDs := make([]string, 0, len(input.Documents))
+	for _, document := range input.Documents {
+		explicitDocumentIDs = append(explicitDocumentIDs, document.DocumentID)
+	}
+
+	documentIDs, err := newIDAllocator("document", explicitDocumentIDs)
+	if err != nil {
+		return EnvelopeDefinition{}, err
+	}
+
+	documents := make([]DocusignDocument, 0, len(input.Documents))
+	for _, document := range input.Documents {
+		document.DocumentID = documentIDs.assign(document.DocumentID)
+
+		docusignDocument, err := loadCustomDocument(documentRoot, document)
+		if err != nil {
+			return EnvelopeDefinition{}, err
+		}
+
+		documents = append(documents, docusignDocument)
+	}
+
+	explicitRecipientIDs := make([]string, 0)
+	for _, group := range [][]DocusignRecipient{input.Signers, input.CarbonCopies, input.CertifiedDeliveries} {
+		for _, recipient := range group {
+			explicitRecipientIDs = append(explicitRecipientIDs, recipient.RecipientID)
+		}
+	}
+	for _, signer := range input.InPersonSigners {
+		explicitRecipientIDs = append(explicitRecipientIDs, signer.RecipientID)
+	}
+
+	recipientIDs, err := newIDAllocator("recipient", explicitRecipientIDs)
+	if err != nil {
+		return EnvelopeDefinition{}, err
+	}
+
+	prepareRecipients := func(recipients []DocusignRecipient) ([]DocusignRecipient, error) {
+		prepared := make([]DocusignRecipient, 0, len(recipients))
+		for _, recipient := range recipients {
+			if err := validateRoutingOrder(recipient.Email, recipient.RoutingOrder); err != nil {
+				return nil, err
+			}
+			recipient.RecipientID = recipientIDs.assign(recipient.RecipientID)
+			prepared = append(prepared, recipient)
+		}
+		return prepared, nil
+	}
+
+	signers, err := prepareRecipients(input.Signers)
+	if err != nil {
+		return EnvelopeDefinition{}, err
+	}
+
+	inPersonSigners := make([]DocusignInPersonSigner, 0, len(input.InPersonSigners))
+	for _, signer := range input.InPersonSigners {
+		if err := validateRoutingOrder(signer.SignerName, signer.RoutingOrder); err != nil {
+			return EnvelopeDefinition{}, err
+		}
+		signer.RecipientID = recipientIDs.assign(signer.RecipientID)
+		inPersonSigners = append(inPersonSigners, signer)
+	}
+
+	carbonCopies, err := prepareRecipients(input.CarbonCopies)
+	if err != nil {
+		return EnvelopeDefinition{}, err
+	}
+
+	certifiedDeliveries, err := prepareRecipients(input.CertifiedDeliveries)
+	if err != nil {
+		return EnvelopeDefinition{}, err
+	}
+
+	return EnvelopeDefinition{
+		Status:       status,
+		EmailSubject: input.EmailSubject,
+		Documents:    documents,
+		Recipients: DocusignRecipients{
+			Signers:             signers,
+			InPersonSigners:     inPersonSigners,
+			CarbonCopies:        carbonCopies,
+			CertifiedDeliveries: certifiedDeliveries,
+		},
+		Notification: input.SigningSchedule.Notification(),
+		CustomFields: managedEnvelopeCustomFields(),
+	}, nil
+}
+
+func loadCustomDocument(documentRoot string, document CustomDocument) (DocusignDocument, error) {
+	name := document.Name
+	if name == "" {
+		name = filepath.Base(document.Path)
+	}
+
+	extension, err := documentExtension(name)
+	if err != nil {
+		return DocusignDocument{}, err
+	}
+
+	content := document.ContentBase64
+	switch {
+	case content != "":
+		if base64.StdEncoding.DecodedLen(len(content)) > MaxInlineDocumentSize {
+			return DocusignDocument{}, newValidationError("document %s exceeds %d bytes of inline content, pass it by path instead", name, MaxInlineDocumentSize)
+		}
+
+		if _, err := base64.StdEncoding.DecodeString(content); err != nil {
+			return DocusignDocument{}, newValidationError("document %s is not valid base64: %v", name, err)
+		}
+	case document.Path != "":
+		path, err := resolveDocumentPath(documentRoot, document.Path)
+		if err != nil {
+			return DocusignDocument{}, err
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return DocusignDocument{}, newValidationError("reading document %s: %v", document.Path, err)
+		}
+		content = base64.StdEncoding.EncodeToString(data)
+	default:
+		return DocusignDocument{}, newValidationError("document %s requires a path or base64 content", name)
+	}
+
+	return DocusignDocument{
+		DocumentBase64: content,
+		DocumentID:     document.DocumentID,
+		FileExtension:  extension,
+		Name:           name,
+	}, nil
+}
+
+func documentExtension(name string) (string, error) {
+	extension := strings.ToLower(strings.TrimPrefix(filepath.Ext(name), "."))
+	if !supportedDocumentExtensions[extension] {
+		return "", newValidationError("document %s has unsupported extension %q", name, extension)
+	}
+
+	return extension, nil
+}
+
+// resolveDocumentPath maps a document path from the workflow input into the
+// document root. The path comes from whoever starts the workflow, so absolute
+// paths, paths leaving the root and files the root only links to are rejected
+// rather than letting the worker mail out any file it can read.
+func resolveDocumentPath(documentRoot string, path string) (string, error) {
+	if documentRoot == "" {
+		return "", newValidationError("document %s cannot be read, no document directory is configured", path)
+	}
+
+	if _, err := documentExtension(path); err != nil {
+		return "", err
+	}
+
+	cleanPath := filepath.Clean(filepath.FromSlash(path))
+	if filepath.IsAbs(cleanPath) || cleanPath == ".." || strings.HasPrefix(cleanPath, ".."+string(filepath.Separator)) {
+		return "", newValidationError("invalid document path: %s", path)
+	}
+
+	root, err := filepath.EvalSymlinks(documentRoot)
+	if err != nil {
+		return "", fmt.Errorf("resolving document directory: %w", err)
+	}
+
+	resolved, err := filepath.EvalSymlinks(filepath.Join(root, cleanPath))
+	if err != nil {
+		return "", newValidationError("reading document %s: %v", path, err)
+	}
+
+	relative, err := filepath.Rel(root, resolved)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", newValidationError("invalid document path: %s", path)
+	}
+
+	return resolved, nil
+}
+
+// idAllocator numbers documents and recipients. Explicit IDs are kept and have
+// to be unique, empty IDs get the lowest number no other entry has taken.
+type idAllocator struct {
+	taken map[string]bool
+	next  int
+}
+
+func newIDAllocator(kind string, explicit []string) (*idAllocator, error) {
+	taken := make(map[string]bool)
+	for _, id := range explicit {
+		if id == "" {
+			continue
+		}
+
+		if taken[id] {
+			return nil, newValidationError("duplicate %s ID %s", kind, id)
+		}
+		taken[id] = true
+	}
+
+	return &idAllocator{taken: taken}, nil
+}
+
+func (a *idAllocator) assign(id string) string {
+	if id != "" {
+		return id
+	}
+
+	for {
+		a.next++
+		candidate := strconv.Itoa(a.next)
+		if !a.taken[candidate] {
+			a.taken[candidate] = true
+			return candidate
+		}
+	}
+}
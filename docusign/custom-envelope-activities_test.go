@@ -0,0 +1,114 @@
+package docusign
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testCustomEnvelopeInput() CustomEnvelopeInput {
+	return CustomEnvelopeInput{
+		Documents: []CustomDocument{
+			{Name: "contract.pdf", ContentBase64: base64.StdEncoding.EncodeToString([]byte("%PDF"))},
+		},
+	}
+}
+
+func TestBuildEnvelopeDefinitionSkipsExplicitRecipientIDs(t *testing.T) {
+	input := testCustomEnvelopeInput()
+	input.Signers = []DocusignRecipient{{RecipientID: "2", Email: "a@example.com"}, {Email: "b@example.com"}}
+	input.CarbonCopies = []DocusignRecipient{{Email: "c@example.com"}}
+
+	definition, err := buildEnvelopeDefinition(input, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []string{
+		definition.Recipients.Signers[0].RecipientID,
+		definition.Recipients.Signers[1].RecipientID,
+		definition.Recipients.CarbonCopies[0].RecipientID,
+	}
+	if strings.Join(ids, ",") != "2,1,3" {
+		t.Fatalf("expected recipient IDs 2,1,3, got %v", ids)
+	}
+}
+
+func TestBuildEnvelopeDefinitionRejectsDuplicateRecipientIDs(t *testing.T) {
+	input := testCustomEnvelopeInput()
+	input.Signers = []DocusignRecipient{{RecipientID: "1", Email: "a@example.com"}}
+	input.CarbonCopies = []DocusignRecipient{{RecipientID: "1", Email: "b@example.com"}}
+
+	if _, err := buildEnvelopeDefinition(input, ""); err == nil {
+		t.Fatal("expected duplicate recipient IDs to be rejected")
+	}
+}
+
+func TestBuildEnvelopeDefinitionRejectsLargeInlineDocuments(t *testing.T) {
+	input := testCustomEnvelopeInput()
+	input.Documents[0].ContentBase64 = base64.StdEncoding.EncodeToString(make([]byte, MaxInlineDocumentSize+1))
+	input.Signers = []DocusignRecipient{{Email: "a@example.com"}}
+
+	if _, err := buildEnvelopeDefinition(input, ""); err == nil {
+		t.Fatal("expected oversized inline content to be rejected")
+	}
+}
+
+func TestBuildEnvelopeDefinitionReadsDocumentsBelowTheRoot(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "contract.pdf"), []byte("%PDF"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	input := testCustomEnvelopeInput()
+	input.Documents = []CustomDocument{{Path: "contract.pdf"}}
+	input.Signers = []DocusignRecipient{{Email: "a@example.com"}}
+
+	definition, err := buildEnvelopeDefinition(input, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if definition.Documents[0].DocumentBase64 != base64.StdEncoding.EncodeToString([]byte("%PDF")) {
+		t.Fatalf("expected the document content, got %q", definition.Documents[0].DocumentBase64)
+	}
+}
+
+func TestBuildEnvelopeDefinitionRejectsPathsOutsideTheRoot(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "documents")
+	if err := os.Mkdir(root, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	secret := filepath.Join(dir, "private.pem")
+	if err := os.WriteFile(secret, []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "outside.pdf"), []byte("%PDF"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "outside.pdf"), filepath.Join(root, "linked.pdf")); err != nil {
+		t.Fatal(err)
+	}
+
+	paths := []string{
+		secret,
+		"../private.pem",
+		"../outside.pdf",
+		filepath.Join(dir, "outside.pdf"),
+		"linked.pdf",
+	}
+
+	for _, path := range paths {
+		input := testCustomEnvelopeInput()
+		input.Documents = []CustomDocument{{Name: "x.pdf", Path: path}}
+		input.Signers = []DocusignRecipient{{Email: "a@example.com"}}
+
+		if _, err := buildEnvelopeDefinition(input, root); err == nil {
+			t.Errorf("expected document path %s to be rejected", path)
+		}
+	}
+}
@@ -2,17 +2,23 @@ package docusign
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"path/filepath"
+	"strings"
+
+	"go.temporal.io/sdk/temporal"
 )
 
 const templateFieldsPath = "/restapi/v2.1/accounts/%s/envelopes/%s/docGenFormFields/"
 
 type TemplateFieldWrapper struct {
-	Type string          `json:"type"`
-	Data json.RawMessage `json:"data"`
+	Type         string                 `json:"type"`
+	Data         json.RawMessage        `json:"data,omitempty"`
+	DocumentID   string                 `json:"documentId,omitempty"`
+	DocumentName string                 `json:"documentName,omitempty"`
+	Documents    []TemplateFieldWrapper `json:"documents,omitempty"`
 }
 
 type FormFieldsDTO struct {
@@ -20,8 +26,19 @@ type FormFieldsDTO struct {
 }
 
 type DocumentFields struct {
-	DocumentID string      `json:"documentId"`
-	Fields     []FormField `json:"docGenFormFieldList"`
+	DocumentID   string      `json:"documentId"`
+	DocumentName string      `json:"-"`
+	Fields       []FormField `json:"docGenFormFieldList"`
+}
+
+func (d DocumentFields) HasRequiredFields() bool {
+	for _, field := range d.Fields {
+		if field.Required == "true" {
+			return true
+		}
+	}
+
+	return false
 }
 
 type FormFields struct {
@@ -29,96 +46,126 @@ type FormFields struct {
 	FieldList  []FormField `json:"docGenFormFieldList"`
 }
 
+const TableRowFieldType = "TableRow"
+
 type FormField struct {
-	Name     string `json:"name"`
+	Name      string            `json:"name"`
+	Value     string            `json:"value,omitempty"`
+	Type      string            `json:"type,omitempty"`
+	Label     string            `json:"label,omitempty"`
+	Required  string            `json:"required,omitempty"`
+	Options   []FormFieldOption `json:"options,omitempty"`
+	RowValues []FormFieldRow    `json:"rowValues,omitempty"`
+}
+
+type FormFieldOption struct {
 	Value    string `json:"value"`
-	Required string `json:"required"`
+	Label    string `json:"label,omitempty"`
+	Selected string `json:"selected,omitempty"`
+}
+
+type FormFieldRow struct {
+	Fields []FormField `json:"docGenFormFieldList"`
+}
+
+func NewTableField(name string, rows ...FormFieldRow) FormField {
+	return FormField{
+		Name:      name,
+		Type:      TableRowFieldType,
+		RowValues: rows,
+	}
+}
+
+func NewTableRow(fields ...FormField) FormFieldRow {
+	return FormFieldRow{Fields: fields}
+}
+
+func (f FormField) IsTable() bool {
+	return f.Type == TableRowFieldType || len(f.RowValues) > 0
+}
+
+func (f FormField) IsFilled() bool {
+	if f.IsTable() {
+		return len(f.RowValues) > 0
+	}
+
+	return f.Value != ""
+}
+
+// Columns returns the column definitions of a remote table field, DocuSign
+// describes them as the single row of the table's rowValues.
+func (f FormField) Columns() []FormField {
+	if len(f.RowValues) == 0 {
+		return nil
+	}
+
+	return f.RowValues[0].Fields
 }
 
 type EnvelopeTemplateFieldGenerator interface {
-	GenerateTemplateFields() FormFieldsDTO
-	SetDocumentID(documentID string)
+	GenerateTemplateFields() (FormFieldsDTO, error)
 }
 
 type NdaTemplateFields struct {
-	VendorName  string `json:"vendorName"`
-	SignerName  string `json:"signerName"`
-	VendorTaxID string `json:"vendorTaxId"`
-	DocumentID  string `json:"documentId"`
+	VendorName  string `json:"vendorName" docgen:"vendorName,required"`
+	SignerName  string `json:"signerName" docgen:"signerName,required"`
+	VendorTaxID string `json:"vendorTaxId" docgen:"vendorTaxId,required"`
+	DocumentID  string `json:"documentId" docgen:"-"`
 }
 
-func (f *NdaTemplateFields) GenerateTemplateFields() FormFieldsDTO {
+func (f *NdaTemplateFields) GenerateTemplateFields() (FormFieldsDTO, error) {
+	fields, err := GenerateDocGenFields(f)
+	if err != nil {
+		return FormFieldsDTO{}, err
+	}
+
 	return FormFieldsDTO{
 		Documents: []DocumentFields{
 			{
 				DocumentID: f.DocumentID,
-				Fields: []FormField{
-					{
-						Name:  "vendorName",
-						Value: f.VendorName,
-					},
-					{
-						Name:  "signerName",
-						Value: f.SignerName,
-					},
-					{
-						Name:  "vendorTaxId",
-						Value: f.VendorTaxID,
-					},
-				},
+				Fields:     fields,
 			},
 		},
-	}
+	}, nil
 }
 
-func (f *NdaTemplateFields) SetDocumentID(documentID string) {
-	f.DocumentID = documentID
-}
-
-func (s *Activities) FillTemplateFields(envelopeSummary EnvelopeSummary, wrapper *TemplateFieldWrapper, user DocusignUser) error {
-	var generator EnvelopeTemplateFieldGenerator
-
-	switch wrapper.Type {
-	case "nda":
-		var nda NdaTemplateFields
-		if err := json.Unmarshal(wrapper.Data, &nda); err != nil {
-			return fmt.Errorf("failed to unmarshal NDA template fields: %v", err)
-		}
-		generator = &nda
-	default:
-		return fmt.Errorf("unknown template type: %s", wrapper.Type)
+func (s *Activities) FillTemplateFields(ctx context.Context, envelopeSummary EnvelopeSummary, wrapper *TemplateFieldWrapper, user DocusignUser) error {
+	generatedDocuments, err := s.generateDocumentFields(wrapper)
+	if err != nil {
+		return err
 	}
 
-	authInfo, err := s.authUpdater.UpdateAuthInfo(user)
+	client, err := s.clientFor(ctx, user)
 	if err != nil {
 		return err
 	}
 
-	envelopeFields, err := s.getTemplateFields(envelopeSummary, user)
+	envelopeFields, err := s.getTemplateFields(ctx, client, envelopeSummary)
 	if err != nil {
 		return err
 	}
 
 	if len(envelopeFields.Documents) == 0 {
 		return fmt.Errorf("no documents found for envelope %s", envelopeSummary.EnvelopeID)
-	} else if len(envelopeFields.Documents) > 1 {
-		fmt.Printf("multiple documents found for envelope %s which is unexpected", envelopeSummary.EnvelopeID)
 	}
 
-	remoteDocument := envelopeFields.Documents[0]
-	generator.SetDocumentID(remoteDocument.DocumentID)
-	formFieldsRequest := generator.GenerateTemplateFields()
+	formFieldsRequest, err := s.resolveDocuments(ctx, client, envelopeSummary, envelopeFields, generatedDocuments)
+	if err != nil {
+		return err
+	}
 
-	if len(formFieldsRequest.Documents) == 0 {
-		return fmt.Errorf("no documents generated for envelope %s", envelopeSummary.EnvelopeID)
-	} else if len(formFieldsRequest.Documents) > 1 {
-		fmt.Printf("multiple documents generated for envelope %s which is unexpected", envelopeSummary.EnvelopeID)
+	remoteDocuments := make(map[string]DocumentFields)
+	for _, document := range envelopeFields.Documents {
+		remoteDocuments[document.DocumentID] = document
 	}
 
-	generatedFields := formFieldsRequest.Documents[0].Fields
+	problems := make([]FieldProblem, 0)
+	for _, document := range formFieldsRequest.Documents {
+		problems = append(problems, validateFields(document.DocumentID, remoteDocuments[document.DocumentID].Fields, document.Fields)...)
+	}
 
-	if err := s.validateFields(remoteDocument.Fields, generatedFields); err != nil {
-		return err
+	if len(problems) > 0 {
+		return newFieldValidationError(problems)
 	}
 
 	formFieldsRequestJSON, err := json.Marshal(formFieldsRequest)
@@ -128,87 +175,179 @@ func (s *Activities) FillTemplateFields(envelopeSummary EnvelopeSummary, wrapper
 
 	fmt.Printf("Form fields request: %v \n", string(formFieldsRequestJSON))
 
-	req, err := http.NewRequest(
+	req, err := client.NewRequest(
+		ctx,
 		"PUT",
-		fmt.Sprintf(s.apiClient.BaseURL+templateFieldsPath, authInfo.AccountId, envelopeSummary.EnvelopeID),
+		fmt.Sprintf(templateFieldsPath, client.AccountID, envelopeSummary.EnvelopeID),
 		bytes.NewBuffer(formFieldsRequestJSON),
 	)
 	if err != nil {
 		return err
 	}
 
-	req.Header = s.apiClient.AuthHeader.Clone()
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := s.apiClient.Client.Do(req)
+	resp, err := s.do(client, req)
 	if err != nil {
 		return err
 	}
 
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to fill template fields: status=%s body=%s", resp.Status, string(bodyBytes))
+	return nil
+}
+
+func (s *Activities) generateDocumentFields(wrapper *TemplateFieldWrapper) ([]DocumentFields, error) {
+	if len(wrapper.Documents) > 0 {
+		documents := make([]DocumentFields, 0, len(wrapper.Documents))
+		for i := range wrapper.Documents {
+			generated, err := s.generateDocumentFields(&wrapper.Documents[i])
+			if err != nil {
+				return nil, err
+			}
+			documents = append(documents, generated...)
+		}
+
+		return documents, nil
 	}
 
-	return nil
+	kind, err := s.templates.Lookup(wrapper.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if kind.NewGenerator == nil {
+		return nil, newValidationError("template type %s has no field generator, provide its documents instead", wrapper.Type)
+	}
+
+	generator := kind.NewGenerator()
+	if err := json.Unmarshal(wrapper.Data, generator); err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("failed to unmarshal %s template fields: %v", wrapper.Type, err),
+			ValidationErrorType,
+			err,
+		)
+	}
+
+	formFields, err := generator.GenerateTemplateFields()
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), ValidationErrorType, err)
+	}
+
+	documents := formFields.Documents
+	if wrapper.DocumentID != "" || wrapper.DocumentName != "" {
+		if len(documents) != 1 {
+			return nil, newValidationError("%s generated %d documents, a document ID or name can only address one", wrapper.Type, len(documents))
+		}
+
+		documents[0].DocumentID = wrapper.DocumentID
+		documents[0].DocumentName = wrapper.DocumentName
+	}
+
+	return documents, nil
 }
 
-func (s *Activities) validateFields(requiredFields []FormField, filledFields []FormField) error {
-	requiredMap := make(map[string]bool)
-	for _, field := range requiredFields {
-		requiredMap[field.Name] = field.Required == "true"
+// resolveDocuments maps every generated document onto a DocGen document of the
+// envelope, either by document ID, by document name or, when the envelope has a
+// single DocGen document, implicitly. Documents with required fields that no
+// generator addressed are reported as errors.
+func (s *Activities) resolveDocuments(ctx context.Context, client *AccountClient, envelopeSummary EnvelopeSummary, envelopeFields FormFieldsDTO, generated []DocumentFields) (FormFieldsDTO, error) {
+	remoteDocuments := make(map[string]DocumentFields)
+	for _, document := range envelopeFields.Documents {
+		remoteDocuments[document.DocumentID] = document
 	}
 
-	filledMap := make(map[string]bool)
-	for _, field := range filledFields {
-		filledMap[field.Name] = field.Value != ""
+	var envelopeDocuments []EnvelopeDocument
+	addressed := make(map[string]bool)
+	resolved := make([]DocumentFields, 0, len(generated))
+
+	for _, document := range generated {
+		documentID := document.DocumentID
+
+		switch {
+		case documentID != "":
+			if _, ok := remoteDocuments[documentID]; !ok {
+				return FormFieldsDTO{}, newValidationError("document %s has no DocGen fields in envelope %s", documentID, envelopeSummary.EnvelopeID)
+			}
+		case document.DocumentName != "":
+			if envelopeDocuments == nil {
+				documents, err := s.getEnvelopeDocuments(ctx, client, envelopeSummary.EnvelopeID)
+				if err != nil {
+					return FormFieldsDTO{}, err
+				}
+				envelopeDocuments = documents.Documents
+			}
+
+			match, ok := findDocumentByName(envelopeDocuments, document.DocumentName)
+			if !ok {
+				return FormFieldsDTO{}, newValidationError("document %q not found in envelope %s", document.DocumentName, envelopeSummary.EnvelopeID)
+			}
+			documentID = match.DocumentID
+
+			if _, ok := remoteDocuments[documentID]; !ok {
+				return FormFieldsDTO{}, newValidationError("document %q has no DocGen fields in envelope %s", document.DocumentName, envelopeSummary.EnvelopeID)
+			}
+		case len(envelopeFields.Documents) == 1:
+			documentID = envelopeFields.Documents[0].DocumentID
+		default:
+			return FormFieldsDTO{}, newValidationError("envelope %s has %d DocGen documents, generated fields must address one by ID or name", envelopeSummary.EnvelopeID, len(envelopeFields.Documents))
+		}
+
+		if addressed[documentID] {
+			return FormFieldsDTO{}, newValidationError("document %s is addressed more than once", documentID)
+		}
+		addressed[documentID] = true
+
+		document.DocumentID = documentID
+		document.DocumentName = ""
+		resolved = append(resolved, document)
 	}
 
-	missingFields := make([]string, 0)
-	for fieldName, required := range requiredMap {
-		if required && !filledMap[fieldName] {
-			missingFields = append(missingFields, fieldName)
+	unaddressed := make([]string, 0)
+	for _, document := range envelopeFields.Documents {
+		if !addressed[document.DocumentID] && document.HasRequiredFields() {
+			unaddressed = append(unaddressed, document.DocumentID)
 		}
 	}
 
-	if len(missingFields) > 0 {
-		return fmt.Errorf("missing required fields: %v", missingFields)
+	if len(unaddressed) > 0 {
+		return FormFieldsDTO{}, newValidationError("documents with required fields were not filled: %v", unaddressed)
 	}
 
-	return nil
+	return FormFieldsDTO{Documents: resolved}, nil
 }
 
-func (s *Activities) getTemplateFields(envelopeSummary EnvelopeSummary, user DocusignUser) (FormFieldsDTO, error) {
-	authInfo, err := s.authUpdater.UpdateAuthInfo(user)
-	if err != nil {
-		return FormFieldsDTO{}, err
+func findDocumentByName(documents []EnvelopeDocument, name string) (EnvelopeDocument, bool) {
+	for _, document := range documents {
+		if strings.EqualFold(document.Name, name) ||
+			strings.EqualFold(strings.TrimSuffix(document.Name, filepath.Ext(document.Name)), name) {
+			return document, true
+		}
 	}
 
-	req, err := http.NewRequest(
+	return EnvelopeDocument{}, false
+}
+
+func newValidationError(format string, args ...interface{}) error {
+	return temporal.NewNonRetryableApplicationError(fmt.Sprintf(format, args...), ValidationErrorType, nil)
+}
+
+func (s *Activities) getTemplateFields(ctx context.Context, client *AccountClient, envelopeSummary EnvelopeSummary) (FormFieldsDTO, error) {
+	req, err := client.NewRequest(
+		ctx,
 		"GET",
-		fmt.Sprintf(s.apiClient.BaseURL+templateFieldsPath, authInfo.AccountId, envelopeSummary.EnvelopeID),
+		fmt.Sprintf(templateFieldsPath, client.AccountID, envelopeSummary.EnvelopeID),
 		nil,
 	)
 	if err != nil {
 		return FormFieldsDTO{}, err
 	}
 
-	req.Header = s.apiClient.AuthHeader.Clone()
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := s.apiClient.Client.Do(req)
+	resp, err := s.do(client, req)
 	if err != nil {
 		return FormFieldsDTO{}, err
 	}
 
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return FormFieldsDTO{}, fmt.Errorf("%s: failed to get template fields: %s", resp.Status, resp.Body)
-	}
-
 	var formFields FormFieldsDTO
 	err = json.NewDecoder(resp.Body).Decode(&formFields)
 	if err != nil {
@@ -0,0 +1,266 @@
+package docusign
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const docgenTag = "docgen"
+
+const DefaultDocGenDateFormat = "2006-01-02"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type docgenTagOptions struct {
+	name     string
+	required bool
+	format   string
+}
+
+// parseDocgenTag reads `docgen:"name,required,format=..."`. Layouts such as
+// "January 2, 2006" contain commas, so format has to be the last option and
+// everything after "format=" is taken as the layout.
+func parseDocgenTag(tag string) docgenTagOptions {
+	name, rest, _ := strings.Cut(tag, ",")
+	options := docgenTagOptions{name: name}
+
+	for rest != "" {
+		if strings.HasPrefix(rest, "format=") {
+			options.format = strings.TrimPrefix(rest, "format=")
+			break
+		}
+
+		var part string
+		part, rest, _ = strings.Cut(rest, ",")
+		if part == "required" {
+			options.required = true
+		}
+	}
+
+	return options
+}
+
+// GenerateDocGenFields turns a struct tagged with `docgen:"name,required,format=..."`
+// into DocGen form fields, format has to be the last option. Untagged nested
+// structs are flattened into the same field list, tagged slices of structs
+// become table fields with one row per element, fields tagged with "-" or
+// without a docgen tag are skipped. Required fields are only marked, empty ones
+// are reported by FillTemplateFields together with every other problem of the
+// envelope's fields.
+func GenerateDocGenFields(v interface{}) ([]FormField, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, fmt.Errorf("docgen: cannot generate fields from a nil pointer")
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("docgen: expected a struct, got %s", value.Kind())
+	}
+
+	fields := make([]FormField, 0, value.NumField())
+
+	if err := appendDocGenFields(value, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func appendDocGenFields(value reflect.Value, fields *[]FormField) error {
+	valueType := value.Type()
+
+	for i := 0; i < valueType.NumField(); i++ {
+		structField := valueType.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		tag, hasTag := structField.Tag.Lookup(docgenTag)
+		if tag == "-" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if hasTag && isDocGenTable(structField.Type) {
+			options := parseDocgenTag(tag)
+			if options.name == "" {
+				options.name = structField.Name
+			}
+
+			field, err := generateDocGenTable(fieldValue, options.name)
+			if err != nil {
+				return err
+			}
+
+			if options.required {
+				field.Required = "true"
+			}
+
+			*fields = append(*fields, field)
+			continue
+		}
+
+		if isNestedDocGenStruct(structField.Type) {
+			nested := fieldValue
+			if nested.Kind() == reflect.Pointer {
+				if nested.IsNil() {
+					continue
+				}
+				nested = nested.Elem()
+			}
+
+			if err := appendDocGenFields(nested, fields); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !hasTag {
+			continue
+		}
+
+		options := parseDocgenTag(tag)
+		if options.name == "" {
+			options.name = structField.Name
+		}
+
+		formatted, err := formatDocGenValue(fieldValue, options)
+		if err != nil {
+			return fmt.Errorf("docgen: field %s: %w", options.name, err)
+		}
+
+		field := FormField{
+			Name:  options.name,
+			Value: formatted,
+		}
+		if options.required {
+			field.Required = "true"
+		}
+
+		*fields = append(*fields, field)
+	}
+
+	return nil
+}
+
+func isDocGenTable(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+		return false
+	}
+
+	return isNestedDocGenStruct(t.Elem())
+}
+
+func generateDocGenTable(value reflect.Value, name string) (FormField, error) {
+	rows := make([]FormFieldRow, 0, value.Len())
+
+	for i := 0; i < value.Len(); i++ {
+		row := value.Index(i)
+		if row.Kind() == reflect.Pointer {
+			if row.IsNil() {
+				continue
+			}
+			row = row.Elem()
+		}
+
+		cells := make([]FormField, 0, row.NumField())
+		if err := appendDocGenFields(row, &cells); err != nil {
+			return FormField{}, err
+		}
+
+		rows = append(rows, NewTableRow(cells...))
+	}
+
+	return NewTableField(name, rows...), nil
+}
+
+func isNestedDocGenStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct && t != timeType
+}
+
+func formatDocGenValue(value reflect.Value, options docgenTagOptions) (string, error) {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return "", nil
+		}
+		value = value.Elem()
+	}
+
+	if value.Type() == timeType {
+		t := value.Interface().(time.Time)
+		if t.IsZero() {
+			return "", nil
+		}
+
+		format := options.format
+		if format == "" {
+			format = DefaultDocGenDateFormat
+		}
+
+		return t.Format(format), nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		if options.format != "" {
+			return fmt.Sprintf(options.format, value.Float()), nil
+		}
+		return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()), nil
+	}
+
+	return "", fmt.Errorf("unsupported type %s", value.Type())
+}
+
+type StructTemplateFields[T any] struct {
+	Fields     T
+	DocumentID string
+}
+
+func (f *StructTemplateFields[T]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &f.Fields)
+}
+
+func (f *StructTemplateFields[T]) GenerateTemplateFields() (FormFieldsDTO, error) {
+	fields, err := GenerateDocGenFields(&f.Fields)
+	if err != nil {
+		return FormFieldsDTO{}, err
+	}
+
+	return FormFieldsDTO{
+		Documents: []DocumentFields{
+			{
+				DocumentID: f.DocumentID,
+				Fields:     fields,
+			},
+		},
+	}, nil
+}
+
+func NewStructTemplateKind[T any](templateType string, templateID string) TemplateKind {
+	return TemplateKind{
+		Type:       templateType,
+		TemplateID: templateID,
+		NewGenerator: func() EnvelopeTemplateFieldGenerator {
+			return &StructTemplateFields[T]{}
+		},
+	}
+}
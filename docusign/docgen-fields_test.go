@@ -0,0 +1,59 @@
+package docusign
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDocgenTagKeepsCommasInFormat(t *testing.T) {
+	options := parseDocgenTag("signedOn,required,format=January 2, 2006")
+
+	if options.name != "signedOn" || !options.required || options.format != "January 2, 2006" {
+		t.Fatalf("unexpected tag options %+v", options)
+	}
+}
+
+func TestGenerateDocGenFieldsFormatsDatesAndNumbers(t *testing.T) {
+	type fields struct {
+		SignedOn  time.Time `docgen:"signedOn,format=January 2, 2006"`
+		StartsOn  time.Time `docgen:"startsOn"`
+		EndsOn    time.Time `docgen:"endsOn"`
+		Fee       float64   `docgen:"fee,required,format=%.2f"`
+		Rate      float64   `docgen:"rate"`
+		Employees int       `docgen:"employees"`
+	}
+
+	generated, err := GenerateDocGenFields(fields{
+		SignedOn:  time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC),
+		StartsOn:  time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+		Fee:       1500,
+		Rate:      0.125,
+		Employees: 42,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"signedOn":  "March 5, 2024",
+		"startsOn":  "2024-04-01",
+		"endsOn":    "",
+		"fee":       "1500.00",
+		"rate":      "0.125",
+		"employees": "42",
+	}
+
+	if len(generated) != len(expected) {
+		t.Fatalf("expected %d fields, got %+v", len(expected), generated)
+	}
+
+	for _, field := range generated {
+		if value, ok := expected[field.Name]; !ok || field.Value != value {
+			t.Errorf("field %s: expected %q, got %q", field.Name, value, field.Value)
+		}
+	}
+
+	if generated[3].Required != "true" {
+		t.Fatalf("expected fee to be marked required, got %+v", generated[3])
+	}
+}
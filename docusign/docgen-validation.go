@@ -0,0 +1,224 @@
+package docusign
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+)
+
+const FieldValidationErrorType = "DocusignFieldValidationError"
+
+const (
+	DateFieldType     = "Date"
+	NumberFieldType   = "Number"
+	CheckboxFieldType = "Checkbox"
+)
+
+const (
+	FieldProblemMissing       = "missing"
+	FieldProblemUnknown       = "unknown"
+	FieldProblemNotATable     = "not_a_table"
+	FieldProblemTableRequired = "table_required"
+	FieldProblemInvalidDate   = "invalid_date"
+	FieldProblemInvalidNumber = "invalid_number"
+	FieldProblemInvalidBool   = "invalid_checkbox"
+	FieldProblemInvalidOption = "invalid_option"
+)
+
+var docGenDateLayouts = []string{
+	DefaultDocGenDateFormat,
+	"01/02/2006",
+	time.RFC3339,
+}
+
+type FieldProblem struct {
+	DocumentID string `json:"documentId"`
+	Field      string `json:"field"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+type FieldValidationError struct {
+	Problems []FieldProblem `json:"problems"`
+}
+
+func (e *FieldValidationError) Error() string {
+	messages := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		messages = append(messages, fmt.Sprintf("document %s: %s", problem.DocumentID, problem.Message))
+	}
+
+	return fmt.Sprintf("%d invalid template fields: %s", len(e.Problems), strings.Join(messages, "; "))
+}
+
+// newFieldValidationError wraps the problems into a non-retryable application
+// error, the problems are attached as details so callers can decode them from
+// the activity failure and present every one of them.
+func newFieldValidationError(problems []FieldProblem) error {
+	validationErr := &FieldValidationError{Problems: problems}
+	return temporal.NewNonRetryableApplicationError(validationErr.Error(), FieldValidationErrorType, validationErr, validationErr)
+}
+
+func validateFields(documentID string, remoteFields []FormField, filledFields []FormField) []FieldProblem {
+	problems := make([]FieldProblem, 0)
+	addProblem := func(field string, code string, format string, args ...interface{}) {
+		problems = append(problems, FieldProblem{
+			DocumentID: documentID,
+			Field:      field,
+			Code:       code,
+			Message:    fmt.Sprintf(format, args...),
+		})
+	}
+
+	remoteMap := make(map[string]FormField)
+	for _, field := range remoteFields {
+		remoteMap[field.Name] = field
+	}
+
+	// Fields are required when the template or the generator marks them so.
+	required := make(map[string]bool)
+	for _, field := range remoteFields {
+		if field.Required == "true" {
+			required[field.Name] = true
+		}
+	}
+
+	filledMap := make(map[string]bool)
+	for _, field := range filledFields {
+		filledMap[field.Name] = field.IsFilled()
+		if field.Required == "true" {
+			required[field.Name] = true
+		}
+
+		remote, ok := remoteMap[field.Name]
+		if !ok {
+			addProblem(field.Name, FieldProblemUnknown, "%s is not a field of the template", field.Name)
+			continue
+		}
+
+		if remote.IsTable() || field.IsTable() {
+			for _, problem := range validateTableField(remote, field) {
+				problem.DocumentID = documentID
+				problems = append(problems, problem)
+			}
+			continue
+		}
+
+		if code, message := validateFieldValue(remote, field.Value); code != "" {
+			addProblem(field.Name, code, "%s %s", field.Name, message)
+		}
+	}
+
+	missing := make([]string, 0)
+	for name := range required {
+		if !filledMap[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+
+	for _, name := range missing {
+		addProblem(name, FieldProblemMissing, "%s is required", name)
+	}
+
+	return problems
+}
+
+func validateTableField(remote FormField, filled FormField) []FieldProblem {
+	if !remote.IsTable() {
+		return []FieldProblem{{Field: filled.Name, Code: FieldProblemNotATable, Message: fmt.Sprintf("%s is not a table field", filled.Name)}}
+	}
+
+	if !filled.IsTable() {
+		return []FieldProblem{{Field: filled.Name, Code: FieldProblemTableRequired, Message: fmt.Sprintf("%s is a table field and requires row values", filled.Name)}}
+	}
+
+	columns := make(map[string]FormField)
+	for _, column := range remote.Columns() {
+		columns[column.Name] = column
+	}
+
+	problems := make([]FieldProblem, 0)
+	for i, row := range filled.RowValues {
+		rowRequired := make(map[string]bool)
+		for name, column := range columns {
+			if column.Required == "true" {
+				rowRequired[name] = true
+			}
+		}
+
+		rowFilled := make(map[string]bool)
+		for _, cell := range row.Fields {
+			if cell.Required == "true" {
+				rowRequired[cell.Name] = true
+			}
+
+			cellName := fmt.Sprintf("%s[%d].%s", filled.Name, i, cell.Name)
+
+			column, ok := columns[cell.Name]
+			if !ok {
+				problems = append(problems, FieldProblem{Field: cellName, Code: FieldProblemUnknown, Message: fmt.Sprintf("%s is not a column of the table", cellName)})
+				continue
+			}
+			rowFilled[cell.Name] = cell.Value != ""
+
+			if code, message := validateFieldValue(column, cell.Value); code != "" {
+				problems = append(problems, FieldProblem{Field: cellName, Code: code, Message: cellName + " " + message})
+			}
+		}
+
+		rowMissing := make([]string, 0)
+		for name := range rowRequired {
+			if _, ok := columns[name]; ok && !rowFilled[name] {
+				rowMissing = append(rowMissing, name)
+			}
+		}
+		sort.Strings(rowMissing)
+
+		for _, name := range rowMissing {
+			cellName := fmt.Sprintf("%s[%d].%s", filled.Name, i, name)
+			problems = append(problems, FieldProblem{Field: cellName, Code: FieldProblemMissing, Message: cellName + " is required"})
+		}
+	}
+
+	return problems
+}
+
+func validateFieldValue(remote FormField, value string) (string, string) {
+	if value == "" {
+		return "", ""
+	}
+
+	switch {
+	case strings.EqualFold(remote.Type, DateFieldType):
+		for _, layout := range docGenDateLayouts {
+			if _, err := time.Parse(layout, value); err == nil {
+				return "", ""
+			}
+		}
+		return FieldProblemInvalidDate, fmt.Sprintf("must be a date, got %q", value)
+	case strings.EqualFold(remote.Type, NumberFieldType):
+		if _, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64); err != nil {
+			return FieldProblemInvalidNumber, fmt.Sprintf("must be a number, got %q", value)
+		}
+	case strings.EqualFold(remote.Type, CheckboxFieldType):
+		if _, err := strconv.ParseBool(value); err != nil {
+			return FieldProblemInvalidBool, fmt.Sprintf("must be true or false, got %q", value)
+		}
+	case len(remote.Options) > 0:
+		allowed := make([]string, 0, len(remote.Options))
+		for _, option := range remote.Options {
+			if option.Value == value {
+				return "", ""
+			}
+			allowed = append(allowed, option.Value)
+		}
+		return FieldProblemInvalidOption, fmt.Sprintf("must be one of %v, got %q", allowed, value)
+	}
+
+	return "", ""
+}
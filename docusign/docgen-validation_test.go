@@ -0,0 +1,70 @@
+package docusign
+
+import (
+	"testing"
+	"time"
+)
+
+type testLineItem struct {
+	Description string `docgen:"description,required"`
+	Amount      string `docgen:"amount"`
+}
+
+type testAgreementFields struct {
+	VendorName string         `docgen:"vendorName,required"`
+	Region     string         `docgen:"region"`
+	StartDate  string         `docgen:"startDate"`
+	Signed     time.Time      `docgen:"signedOn,required"`
+	Extra      string         `docgen:"extra"`
+	Items      []testLineItem `docgen:"items"`
+}
+
+func TestValidateFieldsReportsEveryProblemOfGeneratedFields(t *testing.T) {
+	filled, err := GenerateDocGenFields(&testAgreementFields{
+		Region:    "Mars",
+		StartDate: "tomorrow",
+		Extra:     "x",
+		Items:     []testLineItem{{Amount: "ten"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remote := []FormField{
+		{Name: "vendorName"},
+		{Name: "region", Options: []FormFieldOption{{Value: "EU"}, {Value: "US"}}},
+		{Name: "startDate", Type: DateFieldType},
+		{Name: "signedOn", Type: DateFieldType},
+		NewTableField("items", NewTableRow(
+			FormField{Name: "description"},
+			FormField{Name: "amount", Type: NumberFieldType},
+		)),
+	}
+
+	problems := validateFields("1", remote, filled)
+
+	want := map[string]string{
+		"vendorName":           FieldProblemMissing,
+		"signedOn":             FieldProblemMissing,
+		"region":               FieldProblemInvalidOption,
+		"startDate":            FieldProblemInvalidDate,
+		"extra":                FieldProblemUnknown,
+		"items[0].description": FieldProblemMissing,
+		"items[0].amount":      FieldProblemInvalidNumber,
+	}
+
+	got := make(map[string]string)
+	for _, problem := range problems {
+		got[problem.Field] = problem.Code
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %d problems, got %+v", len(want), problems)
+	}
+
+	for field, code := range want {
+		if got[field] != code {
+			t.Errorf("%s: expected %s, got %q", field, code, got[field])
+		}
+	}
+}
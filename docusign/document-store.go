@@ -0,0 +1,71 @@
+package docusign
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type StoredDocument struct {
+	Location string `json:"location"`
+	Size     int64  `json:"size"`
+	SHA256   string `json:"sha256"`
+}
+
+type DocumentStore interface {
+	Put(ctx context.Context, key string, content io.Reader) (StoredDocument, error)
+}
+
+type LocalDocumentStore struct {
+	root string
+}
+
+func NewLocalDocumentStore(root string) *LocalDocumentStore {
+	return &LocalDocumentStore{
+		root: root,
+	}
+}
+
+func (s *LocalDocumentStore) Put(ctx context.Context, key string, content io.Reader) (StoredDocument, error) {
+	cleanKey := filepath.Clean(filepath.FromSlash(key))
+	if filepath.IsAbs(cleanKey) || cleanKey == ".." || strings.HasPrefix(cleanKey, ".."+string(filepath.Separator)) {
+		return StoredDocument{}, fmt.Errorf("invalid document key: %s", key)
+	}
+
+	path := filepath.Join(s.root, cleanKey)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return StoredDocument{}, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return StoredDocument{}, err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), content)
+	if err != nil {
+		tmp.Close()
+		return StoredDocument{}, err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return StoredDocument{}, err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return StoredDocument{}, err
+	}
+
+	return StoredDocument{
+		Location: path,
+		Size:     size,
+		SHA256:   hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
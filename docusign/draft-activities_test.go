@@ -0,0 +1,63 @@
+package docusign
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListStaleDraftEnvelopesOnlyListsManagedDrafts(t *testing.T) {
+	old := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("custom_field")
+		json.NewEncoder(w).Encode(EnvelopeList{
+			Envelopes: []EnvelopeListItem{
+				{EnvelopeID: "managed", Status: "created", CreatedDateTime: old, CustomFields: managedEnvelopeCustomFields()},
+				{EnvelopeID: "manual", Status: "created", CreatedDateTime: old},
+				{EnvelopeID: "other", Status: "created", CreatedDateTime: old, CustomFields: &EnvelopeCustomFields{
+					TextCustomFields: []TextCustomField{{Name: ManagedEnvelopeFieldName, Value: "other-integration"}},
+				}},
+			},
+			TotalSetSize: "3",
+		})
+	}))
+	defer server.Close()
+
+	activities := NewActivities(staticClientProvider{client: &AccountClient{
+		AccountID: "account",
+		BaseURL:   server.URL,
+		client:    server.Client(),
+	}}, nil, nil, "")
+
+	stale, err := activities.ListStaleDraftEnvelopes(context.Background(), StaleDraftQuery{MaxAge: time.Hour, Lookback: 72 * time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if query != ManagedEnvelopeFieldName+"="+ManagedEnvelopeFieldValue {
+		t.Fatalf("expected the list to be filtered by the marker, got %q", query)
+	}
+
+	if len(stale) != 1 || stale[0].EnvelopeID != "managed" {
+		t.Fatalf("expected only the managed draft, got %+v", stale)
+	}
+}
+
+func TestBuildEnvelopeDefinitionMarksManagedEnvelopes(t *testing.T) {
+	input := testCustomEnvelopeInput()
+	input.Signers = []DocusignRecipient{{Email: "a@example.com"}}
+
+	definition, err := buildEnvelopeDefinition(input, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !(EnvelopeListItem{CustomFields: definition.CustomFields}).Managed() {
+		t.Fatalf("expected the envelope to carry the marker, got %+v", definition.CustomFields)
+	}
+}
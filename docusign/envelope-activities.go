@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 )
 
 type ndaTemplateId string
@@ -15,42 +16,81 @@ const (
 )
 
 type Activities struct {
-	apiClient   *APIClient
-	authUpdater AuthInfoUpdater
+	clients      AccountClientProvider
+	templates    *TemplateRegistry
+	documents    DocumentStore
+	documentRoot string
 }
 
-func NewActivities(apiClient *APIClient, authUpdater AuthInfoUpdater) *Activities {
+// NewActivities creates the activities, custom documents passed by path are
+// only read from below documentRoot.
+func NewActivities(clients AccountClientProvider, templates *TemplateRegistry, documents DocumentStore, documentRoot string) *Activities {
 	return &Activities{
-		apiClient:   apiClient,
-		authUpdater: authUpdater,
+		clients:      clients,
+		templates:    templates,
+		documents:    documents,
+		documentRoot: documentRoot,
 	}
 }
 
+func (s *Activities) clientFor(ctx context.Context, user DocusignUser) (*AccountClient, error) {
+	client, err := s.clients.ClientFor(ctx, user)
+	if err != nil {
+		return nil, authApplicationError(err)
+	}
+
+	return client, nil
+}
+
+func (s *Activities) do(client *AccountClient, req *http.Request) (*http.Response, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, apiApplicationError(err)
+	}
+
+	return resp, nil
+}
+
 const envelopesPath = "/restapi/v2.1/accounts/%s/envelopes/"
 const envelopePath = "/restapi/v2.1/accounts/%s/envelopes/%s"
+const envelopeRecipientsPath = "/restapi/v2.1/accounts/%s/envelopes/%s/recipients"
+const envelopeDocumentsPath = "/restapi/v2.1/accounts/%s/envelopes/%s/documents"
 
 type EnvelopeStatus struct {
-	Status string `json:"status"`
+	Status            string `json:"status"`
+	VoidedReason      string `json:"voidedReason,omitempty"`
+	SentDateTime      string `json:"sentDateTime,omitempty"`
+	CompletedDateTime string `json:"completedDateTime,omitempty"`
 }
 
 type EnvelopeTemplateDefinition struct {
-	TemplateID    string          `json:"templateId"`
-	Status        string          `json:"status"`
-	TemplateRoles []TemplateRoles `json:"templateRoles"`
+	TemplateID    string                `json:"templateId"`
+	Status        string                `json:"status"`
+	EmailSubject  string                `json:"emailSubject,omitempty"`
+	TemplateRoles []TemplateRoles       `json:"templateRoles"`
+	Notification  *EnvelopeNotification `json:"notification,omitempty"`
+	CustomFields  *EnvelopeCustomFields `json:"customFields,omitempty"`
 }
 
 type TemplateRoles struct {
-	Email    string `json:"email"`
-	Name     string `json:"name"`
-	RoleName string `json:"roleName"`
+	Email              string `json:"email"`
+	Name               string `json:"name"`
+	RoleName           string `json:"roleName"`
+	RoutingOrder       string `json:"routingOrder,omitempty"`
+	InPersonSignerName string `json:"inPersonSignerName,omitempty"`
+	HostEmail          string `json:"hostEmail,omitempty"`
+	HostName           string `json:"hostName,omitempty"`
+	ClientUserID       string `json:"clientUserId,omitempty"`
 }
 
 type EnvelopeDefinition struct {
-	Status       string             `json:"status"`
-	EmailSubject string             `json:"emailSubject"`
-	Documents    []DocusignDocument `json:"documents"`
-	Recipients   DocusignRecipients `json:"recipients"`
-	EnvelopeID   string             `json:"envelopeId"`
+	Status       string                `json:"status"`
+	EmailSubject string                `json:"emailSubject"`
+	Documents    []DocusignDocument    `json:"documents"`
+	Recipients   DocusignRecipients    `json:"recipients"`
+	EnvelopeID   string                `json:"envelopeId,omitempty"`
+	Notification *EnvelopeNotification `json:"notification,omitempty"`
+	CustomFields *EnvelopeCustomFields `json:"customFields,omitempty"`
 }
 
 type DocusignDocument struct {
@@ -61,14 +101,64 @@ type DocusignDocument struct {
 }
 
 type DocusignRecipients struct {
-	CarbonCopies []DocusignRecipient `json:"carbonCopies"`
-	Signers      []DocusignRecipient `json:"signers"`
+	CarbonCopies        []DocusignRecipient      `json:"carbonCopies,omitempty"`
+	CertifiedDeliveries []DocusignRecipient      `json:"certifiedDeliveries,omitempty"`
+	InPersonSigners     []DocusignInPersonSigner `json:"inPersonSigners,omitempty"`
+	Signers             []DocusignRecipient      `json:"signers,omitempty"`
 }
 
 type DocusignRecipient struct {
-	Email       string `json:"email"`
-	Name        string `json:"name"`
-	RecipientID string `json:"recipientId"`
+	Email        string        `json:"email"`
+	Name         string        `json:"name"`
+	RecipientID  string        `json:"recipientId"`
+	RoutingOrder string        `json:"routingOrder,omitempty"`
+	ClientUserID string        `json:"clientUserId,omitempty"`
+	Tabs         *DocusignTabs `json:"tabs,omitempty"`
+}
+
+type DocusignInPersonSigner struct {
+	HostEmail    string        `json:"hostEmail"`
+	HostName     string        `json:"hostName"`
+	SignerName   string        `json:"signerName"`
+	SignerEmail  string        `json:"signerEmail,omitempty"`
+	RecipientID  string        `json:"recipientId"`
+	RoutingOrder string        `json:"routingOrder,omitempty"`
+	ClientUserID string        `json:"clientUserId,omitempty"`
+	Tabs         *DocusignTabs `json:"tabs,omitempty"`
+}
+
+type DocusignTabs struct {
+	SignHereTabs    []DocusignTab `json:"signHereTabs,omitempty"`
+	DateSignedTabs  []DocusignTab `json:"dateSignedTabs,omitempty"`
+	InitialHereTabs []DocusignTab `json:"initialHereTabs,omitempty"`
+}
+
+type DocusignTab struct {
+	AnchorString             string `json:"anchorString"`
+	AnchorUnits              string `json:"anchorUnits,omitempty"`
+	AnchorXOffset            string `json:"anchorXOffset,omitempty"`
+	AnchorYOffset            string `json:"anchorYOffset,omitempty"`
+	AnchorIgnoreIfNotPresent string `json:"anchorIgnoreIfNotPresent,omitempty"`
+}
+
+func AnchorTab(anchorString string) DocusignTab {
+	return DocusignTab{
+		AnchorString:             anchorString,
+		AnchorUnits:              "pixels",
+		AnchorIgnoreIfNotPresent: "false",
+	}
+}
+
+type EnvelopeDocument struct {
+	DocumentID string `json:"documentId"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Order      string `json:"order"`
+}
+
+type EnvelopeDocuments struct {
+	EnvelopeID string             `json:"envelopeId"`
+	Documents  []EnvelopeDocument `json:"envelopeDocuments"`
 }
 
 type EnvelopeSummary struct {
@@ -76,22 +166,42 @@ type EnvelopeSummary struct {
 	EnvelopeID string `json:"envelopeId"`
 }
 
-func (s *Activities) CreateNdaEnvelope(ctx context.Context, user DocusignUser) (EnvelopeSummary, error) {
-	authInfo, err := s.authUpdater.UpdateAuthInfo(user)
+func (s *Activities) CreateNdaEnvelope(ctx context.Context, input SendNdaWorkflowInput) (EnvelopeSummary, error) {
+	if len(input.TemplateRoles) == 0 {
+		return EnvelopeSummary{}, newValidationError("at least one template role is required")
+	}
+
+	for _, role := range input.TemplateRoles {
+		if err := validateRoutingOrder(role.RoleName, role.RoutingOrder); err != nil {
+			return EnvelopeSummary{}, err
+		}
+	}
+
+	if err := input.SigningSchedule.Validate(); err != nil {
+		return EnvelopeSummary{}, err
+	}
+
+	client, err := s.clientFor(ctx, input.User)
 	if err != nil {
 		return EnvelopeSummary{}, err
 	}
 
+	templateID := input.TemplateID
+	if templateID == "" {
+		kind, err := s.templates.Lookup(input.TemplateFields.Type)
+		if err != nil {
+			return EnvelopeSummary{}, err
+		}
+		templateID = kind.TemplateID
+	}
+
 	templateDefinition := EnvelopeTemplateDefinition{
-		TemplateID: string(US_NDA_TEMPLATE_ID),
-		Status:     "created",
-		TemplateRoles: []TemplateRoles{
-			{
-				Email:    "alessandrr@my.com",
-				Name:     "Alessandro",
-				RoleName: "Vendor",
-			},
-		},
+		TemplateID:    templateID,
+		Status:        "created",
+		EmailSubject:  input.EmailSubject,
+		TemplateRoles: input.TemplateRoles,
+		Notification:  input.SigningSchedule.Notification(),
+		CustomFields:  managedEnvelopeCustomFields(),
 	}
 
 	templateDefinitionJSON, err := json.Marshal(templateDefinition)
@@ -99,19 +209,17 @@ func (s *Activities) CreateNdaEnvelope(ctx context.Context, user DocusignUser) (
 		return EnvelopeSummary{}, err
 	}
 
-	req, err := http.NewRequest(
+	req, err := client.NewRequest(
+		ctx,
 		"POST",
-		fmt.Sprintf(s.apiClient.BaseURL+envelopesPath, authInfo.AccountId),
+		fmt.Sprintf(envelopesPath, client.AccountID),
 		bytes.NewBuffer(templateDefinitionJSON),
 	)
 	if err != nil {
 		return EnvelopeSummary{}, err
 	}
 
-	req.Header = s.apiClient.AuthHeader.Clone()
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := s.apiClient.Client.Do(req)
+	resp, err := s.do(client, req)
 	if err != nil {
 		return EnvelopeSummary{}, err
 	}
@@ -128,7 +236,7 @@ func (s *Activities) CreateNdaEnvelope(ctx context.Context, user DocusignUser) (
 }
 
 func (s *Activities) SendDraftEnvelope(ctx context.Context, envelopeSummary EnvelopeSummary, user DocusignUser) (EnvelopeSummary, error) {
-	authInfo, err := s.authUpdater.UpdateAuthInfo(user)
+	client, err := s.clientFor(ctx, user)
 	if err != nil {
 		return EnvelopeSummary{}, err
 	}
@@ -142,59 +250,47 @@ func (s *Activities) SendDraftEnvelope(ctx context.Context, envelopeSummary Enve
 		return EnvelopeSummary{}, err
 	}
 
-	req, err := http.NewRequest(
+	req, err := client.NewRequest(
+		ctx,
 		"PUT",
-		fmt.Sprintf(s.apiClient.BaseURL+envelopesPath+envelopeSummary.EnvelopeID, authInfo.AccountId),
+		fmt.Sprintf(envelopePath, client.AccountID, envelopeSummary.EnvelopeID),
 		bytes.NewBuffer(updateBodyJSON),
 	)
 	if err != nil {
 		return EnvelopeSummary{}, err
 	}
 
-	req.Header = s.apiClient.AuthHeader.Clone()
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := s.apiClient.Client.Do(req)
+	resp, err := s.do(client, req)
 	if err != nil {
 		return EnvelopeSummary{}, err
 	}
 
 	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		return EnvelopeSummary{}, fmt.Errorf("%s: failed to send draft envelope: %s", resp.Status, resp.Body)
-	}
-
 	return envelopeSummary, nil
 }
 
 func (s *Activities) GetEnvelopeStatus(ctx context.Context, envelopeID string, user DocusignUser) (EnvelopeStatus, error) {
-	authInfo, err := s.authUpdater.UpdateAuthInfo(user)
+	client, err := s.clientFor(ctx, user)
 	if err != nil {
 		return EnvelopeStatus{}, err
 	}
 
-	req, err := http.NewRequest(
+	req, err := client.NewRequest(
+		ctx,
 		"GET",
-		fmt.Sprintf(s.apiClient.BaseURL+envelopePath, authInfo.AccountId, envelopeID),
+		fmt.Sprintf(envelopePath, client.AccountID, envelopeID),
 		nil,
 	)
 	if err != nil {
 		return EnvelopeStatus{}, err
 	}
 
-	req.Header = s.apiClient.AuthHeader.Clone()
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := s.apiClient.Client.Do(req)
+	resp, err := s.do(client, req)
 	if err != nil {
 		return EnvelopeStatus{}, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return EnvelopeStatus{}, fmt.Errorf("%s: failed to get envelope status: %s", resp.Status, resp.Body)
-	}
-
 	defer resp.Body.Close()
 
 	var envelopeStatus EnvelopeStatus
@@ -207,3 +303,83 @@ func (s *Activities) GetEnvelopeStatus(ctx context.Context, envelopeID string, u
 
 	return envelopeStatus, nil
 }
+
+func (s *Activities) getEnvelopeDocuments(ctx context.Context, client *AccountClient, envelopeID string) (EnvelopeDocuments, error) {
+	req, err := client.NewRequest(
+		ctx,
+		"GET",
+		fmt.Sprintf(envelopeDocumentsPath, client.AccountID, envelopeID),
+		nil,
+	)
+	if err != nil {
+		return EnvelopeDocuments{}, err
+	}
+
+	resp, err := s.do(client, req)
+	if err != nil {
+		return EnvelopeDocuments{}, err
+	}
+
+	defer resp.Body.Close()
+
+	var documents EnvelopeDocuments
+	err = json.NewDecoder(resp.Body).Decode(&documents)
+	if err != nil {
+		return EnvelopeDocuments{}, err
+	}
+
+	return documents, nil
+}
+
+func validateRoutingOrder(recipient string, routingOrder string) error {
+	if routingOrder == "" {
+		return nil
+	}
+
+	order, err := strconv.Atoi(routingOrder)
+	if err != nil || order < 1 {
+		return newValidationError("routing order of %s must be a positive number, got %q", recipient, routingOrder)
+	}
+
+	return nil
+}
+
+func (s *Activities) VoidEnvelope(ctx context.Context, envelopeID string, reason string, user DocusignUser) error {
+	if reason == "" {
+		return newValidationError("a void reason is required")
+	}
+
+	client, err := s.clientFor(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	updateBody := EnvelopeStatus{
+		Status:       "voided",
+		VoidedReason: reason,
+	}
+
+	updateBodyJSON, err := json.Marshal(updateBody)
+	if err != nil {
+		return err
+	}
+
+	req, err := client.NewRequest(
+		ctx,
+		"PUT",
+		fmt.Sprintf(envelopePath, client.AccountID, envelopeID),
+		bytes.NewBuffer(updateBodyJSON),
+	)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(client, req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	return nil
+}
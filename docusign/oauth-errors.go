@@ -0,0 +1,156 @@
+package docusign
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.temporal.io/sdk/temporal"
+)
+
+const (
+	OAuthConsentRequired = "consent_required"
+	OAuthInvalidGrant    = "invalid_grant"
+	OAuthUserNotFound    = "user_not_found"
+)
+
+const (
+	ConsentRequiredErrorType  = "DocusignConsentRequired"
+	InvalidGrantErrorType     = "DocusignInvalidGrant"
+	UserNotFoundErrorType     = "DocusignUserNotFound"
+	AccountSelectionErrorType = "DocusignAccountSelection"
+)
+
+var (
+	ErrConsentRequired = errors.New("docusign: consent required")
+	ErrInvalidGrant    = errors.New("docusign: invalid grant")
+	ErrUserNotFound    = errors.New("docusign: user not found")
+)
+
+var NonRetryableAuthErrorTypes = []string{
+	ConsentRequiredErrorType,
+	InvalidGrantErrorType,
+	UserNotFoundErrorType,
+	AccountSelectionErrorType,
+}
+
+// AccountSelectionError reports that the account to act on could not be
+// determined from the accounts the user belongs to.
+type AccountSelectionError struct {
+	UserID    string
+	AccountID string
+	Available []string
+}
+
+func (e *AccountSelectionError) Error() string {
+	switch {
+	case len(e.Available) == 0:
+		return fmt.Sprintf("no accounts found for user %s", e.UserID)
+	case e.AccountID != "":
+		return fmt.Sprintf("account %s not found for user %s, available accounts: %v", e.AccountID, e.UserID, e.Available)
+	default:
+		return fmt.Sprintf("user %s belongs to %d accounts and none is marked as default, an account ID is required, available accounts: %v", e.UserID, len(e.Available), e.Available)
+	}
+}
+
+type OAuthError struct {
+	StatusCode  int    `json:"-"`
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+	ConsentURL  string `json:"-"`
+}
+
+func parseOAuthError(statusCode int, body []byte) *OAuthError {
+	oauthErr := &OAuthError{StatusCode: statusCode}
+	if err := json.Unmarshal(body, oauthErr); err != nil || oauthErr.Code == "" {
+		oauthErr.Code = "unknown_error"
+		oauthErr.Description = string(body)
+	}
+
+	return oauthErr
+}
+
+// Reason returns the most specific OAuth error code, DocuSign reports some
+// failures such as user_not_found as the description of an invalid_grant.
+func (e *OAuthError) Reason() string {
+	switch e.Description {
+	case OAuthConsentRequired, OAuthUserNotFound:
+		return e.Description
+	}
+
+	return e.Code
+}
+
+func (e *OAuthError) Error() string {
+	msg := fmt.Sprintf("oauth request failed with status code %d: %s", e.StatusCode, e.Code)
+	if e.Description != "" && e.Description != e.Code {
+		msg += " (" + e.Description + ")"
+	}
+
+	if e.ConsentURL != "" {
+		msg += ", grant consent at " + e.ConsentURL
+	}
+
+	return msg
+}
+
+func (e *OAuthError) Is(target error) bool {
+	switch e.Reason() {
+	case OAuthConsentRequired:
+		return target == ErrConsentRequired
+	case OAuthUserNotFound:
+		return target == ErrUserNotFound
+	case OAuthInvalidGrant:
+		return target == ErrInvalidGrant
+	}
+
+	return false
+}
+
+func (e *OAuthError) errorType() string {
+	switch e.Reason() {
+	case OAuthConsentRequired:
+		return ConsentRequiredErrorType
+	case OAuthUserNotFound:
+		return UserNotFoundErrorType
+	case OAuthInvalidGrant:
+		return InvalidGrantErrorType
+	}
+
+	return ""
+}
+
+func ConsentURL(config *DocusignConfig) string {
+	query := url.Values{
+		"response_type": {"code"},
+		"scope":         {strings.Join(config.Scopes, " ")},
+		"client_id":     {config.ClientID},
+	}
+
+	if config.ConsentRedirectURI != "" {
+		query.Set("redirect_uri", config.ConsentRedirectURI)
+	}
+
+	return config.BaseAuthURL + "/oauth/auth?" + query.Encode()
+}
+
+func authApplicationError(err error) error {
+	var selectionErr *AccountSelectionError
+	if errors.As(err, &selectionErr) {
+		return temporal.NewNonRetryableApplicationError(err.Error(), AccountSelectionErrorType, err, selectionErr.Available)
+	}
+
+	var oauthErr *OAuthError
+	if !errors.As(err, &oauthErr) {
+		return err
+	}
+
+	errType := oauthErr.errorType()
+	if errType == "" {
+		return err
+	}
+
+	return temporal.NewNonRetryableApplicationError(err.Error(), errType, err, oauthErr.ConsentURL)
+}
@@ -0,0 +1,155 @@
+package docusign
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRateLimitThreshold = 100
+	DefaultRateLimitMaxWait   = 30 * time.Second
+)
+
+type RateLimitState struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+type RateLimitError struct {
+	AccountID  string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("api quota for account %s nearly exhausted, retry after %s", e.AccountID, e.RetryAfter)
+}
+
+type RateLimiter struct {
+	accounts    map[string]RateLimitState
+	nextAllowed map[string]time.Time
+	threshold   int
+	maxWait     time.Duration
+	mu          sync.Mutex
+}
+
+func NewRateLimiter(threshold int, maxWait time.Duration) *RateLimiter {
+	return &RateLimiter{
+		accounts:    make(map[string]RateLimitState),
+		nextAllowed: make(map[string]time.Time),
+		threshold:   threshold,
+		maxWait:     maxWait,
+	}
+}
+
+func (l *RateLimiter) State(accountID string) (RateLimitState, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	state, ok := l.accounts[accountID]
+	return state, ok
+}
+
+func (l *RateLimiter) Update(accountID string, header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	state := RateLimitState{Remaining: remaining}
+	state.Limit, _ = strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		state.Reset = time.Unix(reset, 0)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.accounts[accountID] = state
+}
+
+// Wait paces requests once an account drops below the threshold by spreading
+// the remaining quota over the time left until the reset. Every caller
+// reserves the next free send slot, so a burst of concurrent requests is
+// spread out instead of firing together once the same delay has passed. When
+// the slot is further away than maxWait it gives up with a RateLimitError so
+// the activity can be retried later instead of holding a worker slot.
+func (l *RateLimiter) Wait(ctx context.Context, accountID string) error {
+	delay, err := l.reserve(accountID)
+	if err != nil {
+		return err
+	}
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *RateLimiter) reserve(accountID string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	state, ok := l.accounts[accountID]
+	if !ok || state.Remaining > l.threshold {
+		return 0, nil
+	}
+
+	now := time.Now()
+	untilReset := state.Reset.Sub(now)
+	if untilReset <= 0 {
+		delete(l.accounts, accountID)
+		delete(l.nextAllowed, accountID)
+		return 0, nil
+	}
+
+	if state.Remaining <= 0 {
+		return 0, &RateLimitError{AccountID: accountID, RetryAfter: untilReset}
+	}
+
+	interval := untilReset / time.Duration(state.Remaining+1)
+
+	slot := l.nextAllowed[accountID]
+	if slot.Before(now) {
+		slot = now
+	}
+
+	delay := slot.Sub(now)
+	if delay > l.maxWait {
+		return 0, &RateLimitError{AccountID: accountID, RetryAfter: delay}
+	}
+
+	l.nextAllowed[accountID] = slot.Add(interval)
+
+	state.Remaining--
+	l.accounts[accountID] = state
+
+	return delay, nil
+}
+
+func retryAfter(header http.Header) time.Duration {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		if delay := time.Until(time.Unix(reset, 0)); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
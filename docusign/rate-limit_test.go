@@ -0,0 +1,91 @@
+package docusign
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func rateLimitHeader(remaining int, reset time.Time) http.Header {
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "1000")
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+	return header
+}
+
+func TestRateLimiterSpreadsConcurrentReservations(t *testing.T) {
+	limiter := NewRateLimiter(100, time.Hour)
+	limiter.Update("account", rateLimitHeader(59, time.Now().Add(time.Hour)))
+
+	const callers = 10
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	delays := make([]time.Duration, 0, callers)
+
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			delay, err := limiter.reserve("account")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			mu.Lock()
+			delays = append(delays, delay)
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	sort.Slice(delays, func(i, j int) bool { return delays[i] < delays[j] })
+
+	// About a minute per request is left, every caller has to get its own slot.
+	for i := 1; i < len(delays); i++ {
+		if gap := delays[i] - delays[i-1]; gap < 50*time.Second {
+			t.Fatalf("reservations %d and %d are only %s apart: %v", i-1, i, gap, delays)
+		}
+	}
+}
+
+func TestRateLimiterGivesUpWithPacingDelay(t *testing.T) {
+	limiter := NewRateLimiter(100, 30*time.Second)
+	limiter.Update("account", rateLimitHeader(59, time.Now().Add(time.Hour)))
+
+	if _, err := limiter.reserve("account"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := limiter.reserve("account")
+
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("expected a RateLimitError, got %v", err)
+	}
+
+	if rateLimitErr.RetryAfter > 2*time.Minute {
+		t.Fatalf("expected to retry after the pacing delay, got %s", rateLimitErr.RetryAfter)
+	}
+}
+
+func TestRateLimiterWaitsForResetWhenExhausted(t *testing.T) {
+	limiter := NewRateLimiter(100, 30*time.Second)
+	limiter.Update("account", rateLimitHeader(0, time.Now().Add(time.Hour)))
+
+	_, err := limiter.reserve("account")
+
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.RetryAfter < 59*time.Minute {
+		t.Fatalf("expected to retry after the reset, got %v", err)
+	}
+}
@@ -0,0 +1,189 @@
+package docusign
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	SignerRecipientType            = "signer"
+	InPersonSignerRecipientType    = "inPersonSigner"
+	CarbonCopyRecipientType        = "carbonCopy"
+	CertifiedDeliveryRecipientType = "certifiedDelivery"
+)
+
+type EnvelopeRecipientsDTO struct {
+	Signers             []RecipientDTO `json:"signers"`
+	InPersonSigners     []RecipientDTO `json:"inPersonSigners"`
+	CarbonCopies        []RecipientDTO `json:"carbonCopies"`
+	CertifiedDeliveries []RecipientDTO `json:"certifiedDeliveries"`
+}
+
+const (
+	RecipientStatusSent          = "sent"
+	RecipientStatusDelivered     = "delivered"
+	RecipientStatusSigned        = "signed"
+	RecipientStatusCompleted     = "completed"
+	RecipientStatusDeclined      = "declined"
+	RecipientStatusAutoResponded = "autoresponded"
+)
+
+type RecipientDTO struct {
+	RecipientID         string `json:"recipientId"`
+	Name                string `json:"name"`
+	Email               string `json:"email"`
+	SignerName          string `json:"signerName"`
+	SignerEmail         string `json:"signerEmail"`
+	HostName            string `json:"hostName"`
+	HostEmail           string `json:"hostEmail"`
+	RoutingOrder        string `json:"routingOrder"`
+	ClientUserID        string `json:"clientUserId"`
+	Status              string `json:"status"`
+	SentDateTime        string `json:"sentDateTime"`
+	DeliveredDateTime   string `json:"deliveredDateTime"`
+	SignedDateTime      string `json:"signedDateTime"`
+	DeclinedDateTime    string `json:"declinedDateTime"`
+	DeclinedReason      string `json:"declinedReason"`
+	AutoRespondedReason string `json:"autoRespondedReason"`
+}
+
+type RecipientProgress struct {
+	RecipientID         string    `json:"recipientId"`
+	Type                string    `json:"type"`
+	Name                string    `json:"name"`
+	Email               string    `json:"email"`
+	HostName            string    `json:"hostName,omitempty"`
+	HostEmail           string    `json:"hostEmail,omitempty"`
+	RoutingOrder        int       `json:"routingOrder"`
+	ClientUserID        string    `json:"clientUserId,omitempty"`
+	Status              string    `json:"status"`
+	SentAt              time.Time `json:"sentAt"`
+	DeliveredAt         time.Time `json:"deliveredAt"`
+	SignedAt            time.Time `json:"signedAt"`
+	DeclinedAt          time.Time `json:"declinedAt"`
+	DeclinedReason      string    `json:"declinedReason,omitempty"`
+	AutoRespondedReason string    `json:"autoRespondedReason,omitempty"`
+}
+
+func (p RecipientProgress) Declined() bool {
+	return p.Status == RecipientStatusDeclined
+}
+
+func (p RecipientProgress) Bounced() bool {
+	return p.Status == RecipientStatusAutoResponded
+}
+
+// Embedded reports whether the recipient signs inside our own application
+// rather than from the DocuSign email.
+func (p RecipientProgress) Embedded() bool {
+	return p.ClientUserID != ""
+}
+
+func parseDocusignTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
+
+func (r RecipientDTO) progress(recipientType string) RecipientProgress {
+	name, email := r.Name, r.Email
+	hostName, hostEmail := "", ""
+	if recipientType == InPersonSignerRecipientType {
+		hostName, hostEmail = r.HostName, r.HostEmail
+		name = r.SignerName
+		if r.SignerEmail != "" {
+			email = r.SignerEmail
+		} else {
+			email = r.HostEmail
+		}
+	}
+
+	routingOrder, _ := strconv.Atoi(r.RoutingOrder)
+
+	return RecipientProgress{
+		RecipientID:         r.RecipientID,
+		Type:                recipientType,
+		Name:                name,
+		Email:               email,
+		HostName:            hostName,
+		HostEmail:           hostEmail,
+		RoutingOrder:        routingOrder,
+		ClientUserID:        r.ClientUserID,
+		Status:              r.Status,
+		SentAt:              parseDocusignTime(r.SentDateTime),
+		DeliveredAt:         parseDocusignTime(r.DeliveredDateTime),
+		SignedAt:            parseDocusignTime(r.SignedDateTime),
+		DeclinedAt:          parseDocusignTime(r.DeclinedDateTime),
+		DeclinedReason:      r.DeclinedReason,
+		AutoRespondedReason: r.AutoRespondedReason,
+	}
+}
+
+func (r EnvelopeRecipientsDTO) Progress() []RecipientProgress {
+	progress := make([]RecipientProgress, 0, len(r.Signers)+len(r.InPersonSigners)+len(r.CarbonCopies)+len(r.CertifiedDeliveries))
+
+	groups := []struct {
+		recipientType string
+		recipients    []RecipientDTO
+	}{
+		{SignerRecipientType, r.Signers},
+		{InPersonSignerRecipientType, r.InPersonSigners},
+		{CarbonCopyRecipientType, r.CarbonCopies},
+		{CertifiedDeliveryRecipientType, r.CertifiedDeliveries},
+	}
+
+	for _, group := range groups {
+		for _, recipient := range group.recipients {
+			progress = append(progress, recipient.progress(group.recipientType))
+		}
+	}
+
+	sort.SliceStable(progress, func(i, j int) bool {
+		return progress[i].RoutingOrder < progress[j].RoutingOrder
+	})
+
+	return progress
+}
+
+func (s *Activities) GetEnvelopeRecipients(ctx context.Context, envelopeID string, user DocusignUser) ([]RecipientProgress, error) {
+	client, err := s.clientFor(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := client.NewRequest(
+		ctx,
+		"GET",
+		fmt.Sprintf(envelopeRecipientsPath, client.AccountID, envelopeID),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(client, req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	var recipients EnvelopeRecipientsDTO
+	err = json.NewDecoder(resp.Body).Decode(&recipients)
+	if err != nil {
+		return nil, err
+	}
+
+	return recipients.Progress(), nil
+}
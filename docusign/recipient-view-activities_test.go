@@ -0,0 +1,57 @@
+package docusign
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type staticClientProvider struct {
+	client *AccountClient
+}
+
+func (p staticClientProvider) ClientFor(ctx context.Context, user DocusignUser) (*AccountClient, error) {
+	return p.client, nil
+}
+
+func TestCreateRecipientViewUsesHostOfInPersonSigner(t *testing.T) {
+	var received RecipientViewRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(RecipientView{URL: "https://demo.docusign.net/signing"})
+	}))
+	defer server.Close()
+
+	activities := NewActivities(staticClientProvider{client: &AccountClient{
+		AccountID: "account",
+		BaseURL:   server.URL,
+		client:    server.Client(),
+	}}, nil, nil, "")
+
+	recipient := RecipientDTO{
+		RecipientID:  "1",
+		SignerName:   "Vendor Signer",
+		SignerEmail:  "signer@example.com",
+		HostName:     "Office Host",
+		HostEmail:    "host@example.com",
+		ClientUserID: "vendor-1",
+	}.progress(InPersonSignerRecipientType)
+
+	view, err := activities.CreateRecipientView(context.Background(), "envelope", recipient, "https://portal.example.com/done", DocusignUser{UserID: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if view.URL == "" {
+		t.Fatal("expected a signing URL")
+	}
+
+	if received.UserName != "Office Host" || received.Email != "host@example.com" || received.ClientUserID != "vendor-1" {
+		t.Fatalf("expected the view to be requested for the host, got %+v", received)
+	}
+}
@@ -0,0 +1,103 @@
+package docusign
+
+import (
+	"strconv"
+	"time"
+)
+
+const ExpiredEnvelopeStatus = "expired"
+
+const ExpiredVoidReason = "Signing deadline has passed"
+
+// SigningSchedule describes when DocuSign reminds recipients and when the
+// envelope expires. DocuSign only supports whole days for both.
+type SigningSchedule struct {
+	ExpireAfterDays       int  `json:"expireAfterDays,omitempty"`
+	ExpireWarnDays        int  `json:"expireWarnDays,omitempty"`
+	ReminderDelayDays     int  `json:"reminderDelayDays,omitempty"`
+	ReminderFrequencyDays int  `json:"reminderFrequencyDays,omitempty"`
+	VoidOnExpiry          bool `json:"voidOnExpiry,omitempty"`
+}
+
+type EnvelopeNotification struct {
+	UseAccountDefaults string               `json:"useAccountDefaults"`
+	Reminders          *EnvelopeReminders   `json:"reminders,omitempty"`
+	Expirations        *EnvelopeExpirations `json:"expirations,omitempty"`
+}
+
+type EnvelopeReminders struct {
+	ReminderEnabled   string `json:"reminderEnabled"`
+	ReminderDelay     string `json:"reminderDelay"`
+	ReminderFrequency string `json:"reminderFrequency"`
+}
+
+type EnvelopeExpirations struct {
+	ExpireEnabled string `json:"expireEnabled"`
+	ExpireAfter   string `json:"expireAfter"`
+	ExpireWarn    string `json:"expireWarn,omitempty"`
+}
+
+func (s SigningSchedule) IsZero() bool {
+	return s == SigningSchedule{}
+}
+
+func (s SigningSchedule) Validate() error {
+	if s.ExpireAfterDays < 0 || s.ExpireWarnDays < 0 || s.ReminderDelayDays < 0 || s.ReminderFrequencyDays < 0 {
+		return newValidationError("signing schedule days must not be negative")
+	}
+
+	if s.ExpireWarnDays > 0 && s.ExpireWarnDays >= s.ExpireAfterDays {
+		return newValidationError("expiration warning (%d days) must be sent before the envelope expires (%d days)", s.ExpireWarnDays, s.ExpireAfterDays)
+	}
+
+	if s.VoidOnExpiry && s.ExpireAfterDays == 0 {
+		return newValidationError("voiding on expiry requires a signing deadline")
+	}
+
+	if s.ReminderFrequencyDays > 0 && s.ReminderDelayDays == 0 {
+		return newValidationError("a reminder frequency requires a reminder delay")
+	}
+
+	return nil
+}
+
+// Notification translates the schedule into the envelope notification
+// settings, nil keeps the account defaults.
+func (s SigningSchedule) Notification() *EnvelopeNotification {
+	if s.ExpireAfterDays == 0 && s.ReminderDelayDays == 0 {
+		return nil
+	}
+
+	notification := &EnvelopeNotification{UseAccountDefaults: "false"}
+
+	if s.ReminderDelayDays > 0 {
+		notification.Reminders = &EnvelopeReminders{
+			ReminderEnabled:   "true",
+			ReminderDelay:     strconv.Itoa(s.ReminderDelayDays),
+			ReminderFrequency: strconv.Itoa(s.ReminderFrequencyDays),
+		}
+	}
+
+	if s.ExpireAfterDays > 0 {
+		notification.Expirations = &EnvelopeExpirations{
+			ExpireEnabled: "true",
+			ExpireAfter:   strconv.Itoa(s.ExpireAfterDays),
+		}
+
+		if s.ExpireWarnDays > 0 {
+			notification.Expirations.ExpireWarn = strconv.Itoa(s.ExpireWarnDays)
+		}
+	}
+
+	return notification
+}
+
+// Deadline returns the moment the envelope expires when sent at sentAt, the
+// zero time when the schedule has no deadline.
+func (s SigningSchedule) Deadline(sentAt time.Time) time.Time {
+	if s.ExpireAfterDays == 0 {
+		return time.Time{}
+	}
+
+	return sentAt.AddDate(0, 0, s.ExpireAfterDays)
+}
@@ -0,0 +1,96 @@
+package docusign
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.temporal.io/sdk/temporal"
+)
+
+const UnknownTemplateTypeErrorType = "DocusignUnknownTemplateType"
+
+const NdaTemplateType = "nda"
+
+type TemplateKind struct {
+	Type         string
+	TemplateID   string
+	NewGenerator func() EnvelopeTemplateFieldGenerator
+}
+
+type TemplateRegistry struct {
+	kinds map[string]TemplateKind
+	mu    sync.RWMutex
+}
+
+func NewTemplateRegistry() *TemplateRegistry {
+	return &TemplateRegistry{
+		kinds: make(map[string]TemplateKind),
+	}
+}
+
+func NewDefaultTemplateRegistry() *TemplateRegistry {
+	registry := NewTemplateRegistry()
+	registry.MustRegister(TemplateKind{
+		Type:       NdaTemplateType,
+		TemplateID: string(US_NDA_TEMPLATE_ID),
+		NewGenerator: func() EnvelopeTemplateFieldGenerator {
+			return &NdaTemplateFields{}
+		},
+	})
+
+	return registry
+}
+
+func (r *TemplateRegistry) Register(kind TemplateKind) error {
+	if kind.Type == "" {
+		return fmt.Errorf("template kind requires a type")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.kinds[kind.Type]; ok {
+		return fmt.Errorf("template type %s is already registered", kind.Type)
+	}
+
+	r.kinds[kind.Type] = kind
+
+	return nil
+}
+
+func (r *TemplateRegistry) MustRegister(kind TemplateKind) {
+	if err := r.Register(kind); err != nil {
+		panic(err)
+	}
+}
+
+func (r *TemplateRegistry) Lookup(templateType string) (TemplateKind, error) {
+	r.mu.RLock()
+	kind, ok := r.kinds[templateType]
+	r.mu.RUnlock()
+
+	if !ok {
+		return TemplateKind{}, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("unknown template type %q, registered types: %s", templateType, strings.Join(r.Types(), ", ")),
+			UnknownTemplateTypeErrorType,
+			nil,
+		)
+	}
+
+	return kind, nil
+}
+
+func (r *TemplateRegistry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	types := make([]string, 0, len(r.kinds))
+	for templateType := range r.kinds {
+		types = append(types, templateType)
+	}
+	sort.Strings(types)
+
+	return types
+}
@@ -0,0 +1,274 @@
+package docusign
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// The selectable backends only cover what a worker can run on its own, a
+// cache shared through a key-value store is built with NewKVTokenCache and
+// passed to NewAuthService by the embedding application.
+const (
+	MemoryTokenCacheBackend = "memory"
+	FileTokenCacheBackend   = "file"
+)
+
+const DefaultKVTokenCachePrefix = "docusign:token:"
+const kvTokenCacheTimeout = 5 * time.Second
+
+func NewTokenCache(config *DocusignConfig) (TokenCache[DocusignUser, DocusignUserCacheEntry], error) {
+	switch config.TokenCacheBackend {
+	case "", MemoryTokenCacheBackend:
+		return NewKeysCache(1 * time.Minute), nil
+	case FileTokenCacheBackend:
+		return NewFileTokenCache(config.TokenCacheFile, config.TokenCacheEncryptionKey)
+	}
+
+	return nil, fmt.Errorf("unknown token cache backend: %s", config.TokenCacheBackend)
+}
+
+type FileTokenCache struct {
+	path string
+	aead cipher.AEAD
+	mu   sync.Mutex
+}
+
+func NewFileTokenCache(path string, encryptionKey string) (*FileTokenCache, error) {
+	if path == "" {
+		return nil, errors.New("token cache file path is required")
+	}
+
+	if encryptionKey == "" {
+		return nil, errors.New("token cache encryption key is required")
+	}
+
+	key := sha256.Sum256([]byte(encryptionKey))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileTokenCache{
+		path: path,
+		aead: aead,
+	}, nil
+}
+
+func (c *FileTokenCache) Get(user DocusignUser) (DocusignUserCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := c.load()
+	if err != nil {
+		fmt.Printf("Error reading token cache file: %s\n", err)
+		return DocusignUserCacheEntry{}, false
+	}
+
+	entry, ok := entries[user.String()]
+	if !ok || entry.Expired() {
+		return DocusignUserCacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+func (c *FileTokenCache) Set(user DocusignUser, entry DocusignUserCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := c.load()
+	if err != nil {
+		fmt.Printf("Error reading token cache file, starting a new one: %s\n", err)
+		entries = make(map[string]DocusignUserCacheEntry)
+	}
+
+	for k, v := range entries {
+		if v.Expired() {
+			delete(entries, k)
+		}
+	}
+
+	entries[user.String()] = entry
+
+	if err := c.save(entries); err != nil {
+		fmt.Printf("Error writing token cache file: %s\n", err)
+	}
+}
+
+func (c *FileTokenCache) Delete(user DocusignUser) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := c.load()
+	if err != nil {
+		fmt.Printf("Error reading token cache file: %s\n", err)
+		return
+	}
+
+	if _, ok := entries[user.String()]; !ok {
+		return
+	}
+
+	delete(entries, user.String())
+
+	if err := c.save(entries); err != nil {
+		fmt.Printf("Error writing token cache file: %s\n", err)
+	}
+}
+
+func (c *FileTokenCache) Start() {}
+
+func (c *FileTokenCache) Stop() {}
+
+func (c *FileTokenCache) load() (map[string]DocusignUserCacheEntry, error) {
+	entries := make(map[string]DocusignUserCacheEntry)
+
+	ciphertext, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := c.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errors.New("token cache file is truncated")
+	}
+
+	plaintext, err := c.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting token cache: %w", err)
+	}
+
+	if err := json.Unmarshal(plaintext, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (c *FileTokenCache) save(entries map[string]DocusignUserCacheEntry) error {
+	plaintext, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+
+	ciphertext := c.aead.Seal(nonce, nonce, plaintext, nil)
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(ciphertext); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.path)
+}
+
+// KeyValueStore is the shared store, such as Redis, behind a KVTokenCache.
+type KeyValueStore interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+type KVTokenCache struct {
+	store  KeyValueStore
+	prefix string
+}
+
+func NewKVTokenCache(store KeyValueStore, prefix string) *KVTokenCache {
+	return &KVTokenCache{
+		store:  store,
+		prefix: prefix,
+	}
+}
+
+func (c *KVTokenCache) Get(user DocusignUser) (DocusignUserCacheEntry, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), kvTokenCacheTimeout)
+	defer cancel()
+
+	data, ok, err := c.store.Get(ctx, c.prefix+user.String())
+	if err != nil {
+		fmt.Printf("Error reading token cache: %s\n", err)
+		return DocusignUserCacheEntry{}, false
+	}
+	if !ok {
+		return DocusignUserCacheEntry{}, false
+	}
+
+	var entry DocusignUserCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		fmt.Printf("Error decoding token cache entry: %s\n", err)
+		return DocusignUserCacheEntry{}, false
+	}
+
+	if entry.Expired() {
+		return DocusignUserCacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+func (c *KVTokenCache) Set(user DocusignUser, entry DocusignUserCacheEntry) {
+	ttl := entry.Remaining()
+	if ttl <= 0 {
+		return
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		fmt.Printf("Error encoding token cache entry: %s\n", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kvTokenCacheTimeout)
+	defer cancel()
+
+	if err := c.store.Set(ctx, c.prefix+user.String(), data, ttl); err != nil {
+		fmt.Printf("Error writing token cache: %s\n", err)
+	}
+}
+
+func (c *KVTokenCache) Delete(user DocusignUser) {
+	ctx, cancel := context.WithTimeout(context.Background(), kvTokenCacheTimeout)
+	defer cancel()
+
+	if err := c.store.Delete(ctx, c.prefix+user.String()); err != nil {
+		fmt.Printf("Error deleting token cache entry: %s\n", err)
+	}
+}
+
+func (c *KVTokenCache) Start() {}
+
+func (c *KVTokenCache) Stop() {}
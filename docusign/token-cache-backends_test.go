@@ -0,0 +1,90 @@
+package docusign
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+type memoryKeyValue struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// memoryKeyValueStore stands in for a shared store such as Redis in tests.
+type memoryKeyValueStore struct {
+	values map[string]memoryKeyValue
+	mu     sync.Mutex
+}
+
+func newMemoryKeyValueStore() *memoryKeyValueStore {
+	return &memoryKeyValueStore{
+		values: make(map[string]memoryKeyValue),
+	}
+}
+
+func (s *memoryKeyValueStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.values[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	if !value.expiresAt.IsZero() && time.Now().After(value.expiresAt) {
+		delete(s.values, key)
+		return nil, false, nil
+	}
+
+	return value.value, true, nil
+}
+
+func (s *memoryKeyValueStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := memoryKeyValue{value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+
+	s.values[key] = entry
+
+	return nil
+}
+
+func (s *memoryKeyValueStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.values, key)
+
+	return nil
+}
+
+func TestNewTokenCacheRejectsKVBackend(t *testing.T) {
+	config := &DocusignConfig{TokenCacheBackend: "kv"}
+
+	if _, err := NewTokenCache(config); err == nil {
+		t.Fatal("expected the kv backend not to be selectable")
+	}
+}
+
+func TestKVTokenCache(t *testing.T) {
+	cache := NewKVTokenCache(newMemoryKeyValueStore(), DefaultKVTokenCachePrefix)
+
+	user := DocusignUser{UserID: "alice"}
+	cache.Set(user, DocusignUserCacheEntry{AccessToken: "token", CreatedAt: time.Now(), TTL: time.Hour})
+
+	if entry, ok := cache.Get(user); !ok || entry.AccessToken != "token" {
+		t.Fatalf("expected the cached token, got %+v", entry)
+	}
+
+	cache.Delete(user)
+
+	if _, ok := cache.Get(user); ok {
+		t.Fatal("expected the token to be deleted")
+	}
+}
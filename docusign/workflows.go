@@ -1,83 +1,680 @@
 package docusign
 
 import (
-	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
 	"time"
 
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
 )
 
-func SendNdaWorkflow(ctx workflow.Context) (string, error) {
-	ao := workflow.ActivityOptions{
+type SendNdaWorkflowInput struct {
+	User            DocusignUser         `json:"user"`
+	TemplateID      string               `json:"templateId"`
+	TemplateRoles   []TemplateRoles      `json:"templateRoles"`
+	TemplateFields  TemplateFieldWrapper `json:"templateFields"`
+	EmailSubject    string               `json:"emailSubject"`
+	SigningSchedule SigningSchedule      `json:"signingSchedule"`
+}
+
+const EnvelopePollFallbackInterval = time.Hour
+
+const RecipientProgressQuery = "recipient-progress"
+const SigningStatusQuery = "signing-status"
+const SendNdaResultQuery = "send-nda-result"
+
+const SigningProgressSignal = "signing-progress"
+
+const VoidEnvelopeSignal = "void"
+
+const (
+	DefaultVoidReason   = "Voided on request"
+	CancelledVoidReason = "Signing process was cancelled"
+)
+
+type VoidRequest struct {
+	Reason string `json:"reason"`
+}
+
+type WaitForSigningResult struct {
+	EnvelopeID     string              `json:"envelopeId"`
+	Status         string              `json:"status"`
+	SentAt         time.Time           `json:"sentAt"`
+	CompletedAt    time.Time           `json:"completedAt"`
+	DeclinedReason string              `json:"declinedReason,omitempty"`
+	VoidedReason   string              `json:"voidedReason,omitempty"`
+	ExpiredAt      time.Time           `json:"expiredAt"`
+	Recipients     []RecipientProgress `json:"recipients"`
+	Corrections    []AppliedCorrection `json:"corrections,omitempty"`
+	Documents      []ArchivedDocument  `json:"documents,omitempty"`
+}
+
+func (r *WaitForSigningResult) recipient(recipientID string) (int, bool) {
+	for i, recipient := range r.Recipients {
+		if recipient.RecipientID == recipientID {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// pendingRecipient returns a recipient of an envelope that is still out for
+// signature and who has not acted on it yet.
+func (r *WaitForSigningResult) pendingRecipient(recipientID string) (RecipientProgress, error) {
+	if isFinalEnvelopeStatus(r.Status) || r.Status == ExpiredEnvelopeStatus {
+		return RecipientProgress{}, fmt.Errorf("envelope %s is %s and no longer accepts changes", r.EnvelopeID, r.Status)
+	}
+
+	i, ok := r.recipient(recipientID)
+	if !ok {
+		return RecipientProgress{}, fmt.Errorf("recipient %s not found in envelope %s", recipientID, r.EnvelopeID)
+	}
+
+	recipient := r.Recipients[i]
+	switch recipient.Status {
+	case RecipientStatusSigned, RecipientStatusCompleted, RecipientStatusDeclined:
+		return RecipientProgress{}, fmt.Errorf("recipient %s has already %s", recipientID, recipient.Status)
+	}
+
+	return recipient, nil
+}
+
+func (r *WaitForSigningResult) setRecipients(recipients []RecipientProgress) {
+	r.Recipients = recipients
+	for _, recipient := range recipients {
+		if recipient.Declined() && recipient.DeclinedReason != "" {
+			r.DeclinedReason = recipient.DeclinedReason
+		}
+	}
+}
+
+// progressSnapshot copies the parts of the result a parent is told about. The
+// recipients are cloned because corrections update them in place.
+func (r WaitForSigningResult) progressSnapshot() WaitForSigningResult {
+	r.Recipients = slices.Clone(r.Recipients)
+	r.Corrections = slices.Clone(r.Corrections)
+	return r
+}
+
+func (r WaitForSigningResult) progressChanged(previous WaitForSigningResult) bool {
+	return r.Status != previous.Status ||
+		!reflect.DeepEqual(r.Recipients, previous.Recipients) ||
+		len(r.Corrections) != len(previous.Corrections)
+}
+
+// SendNdaResult describes the outcome of SendNdaWorkflow. While the envelope
+// is out for signature the signing workflow reports its progress back, so the
+// query reflects the live status and recipients.
+type SendNdaResult struct {
+	WaitForSigningResult
+}
+
+func (r *SendNdaResult) setSigningResult(signing WaitForSigningResult) {
+	sentAt := r.SentAt
+	r.WaitForSigningResult = signing
+	if r.SentAt.IsZero() {
+		r.SentAt = sentAt
+	}
+}
+
+const (
+	DefaultDraftMaxAge   = 24 * time.Hour
+	DefaultDraftLookback = 30 * 24 * time.Hour
+)
+
+type DraftSweeperInput struct {
+	User     DocusignUser  `json:"user"`
+	MaxAge   time.Duration `json:"maxAge"`
+	Lookback time.Duration `json:"lookback"`
+	Interval time.Duration `json:"interval"`
+}
+
+func (i DraftSweeperInput) withDefaults() DraftSweeperInput {
+	if i.MaxAge <= 0 {
+		i.MaxAge = DefaultDraftMaxAge
+	}
+
+	if i.Lookback <= i.MaxAge {
+		i.Lookback = i.MaxAge + DefaultDraftLookback
+	}
+
+	return i
+}
+
+type WaitForSigningInput struct {
+	EnvelopeID   string       `json:"envelopeId"`
+	User         DocusignUser `json:"user"`
+	Deadline     time.Time    `json:"deadline"`
+	VoidOnExpiry bool         `json:"voidOnExpiry,omitempty"`
+}
+
+func defaultActivityOptions() workflow.ActivityOptions {
+	return workflow.ActivityOptions{
 		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			NonRetryableErrorTypes: NonRetryableAuthErrorTypes,
+		},
 	}
-	ctx = workflow.WithActivityOptions(ctx, ao)
+}
+
+func archiveActivityOptions() workflow.ActivityOptions {
+	ao := defaultActivityOptions()
+	ao.StartToCloseTimeout = 10 * time.Minute
+
+	return ao
+}
+
+func SendNdaWorkflow(ctx workflow.Context, input SendNdaWorkflowInput) (SendNdaResult, error) {
+	ctx = workflow.WithActivityOptions(ctx, defaultActivityOptions())
 
 	var activities *Activities
 
+	result := SendNdaResult{
+		WaitForSigningResult: WaitForSigningResult{
+			Recipients: make([]RecipientProgress, 0),
+		},
+	}
+
+	err := workflow.SetQueryHandler(ctx, SendNdaResultQuery, func() (SendNdaResult, error) {
+		return result, nil
+	})
+	if err != nil {
+		return result, err
+	}
+
 	var envelopeSummary EnvelopeSummary
-	err := workflow.ExecuteActivity(ctx, activities.CreateNdaEnvelope, DocusignTestUser).Get(ctx, &envelopeSummary)
+	err = workflow.ExecuteActivity(ctx, activities.CreateNdaEnvelope, input).Get(ctx, &envelopeSummary)
 	if err != nil {
-		return "", err
+		return result, err
 	}
 
-	ndaFields := NdaTemplateFields{
-		VendorName:  "Hello",
-		VendorTaxID: "1234567890",
-		SignerName:  "World",
+	result.EnvelopeID = envelopeSummary.EnvelopeID
+	result.Status = envelopeSummary.Status
+
+	// From here on a draft exists in the DocuSign account, any step that fails
+	// for good has to remove it again so it is not left orphaned.
+	discardDraft := func(cause error) error {
+		disconnectedCtx, cancel := workflow.NewDisconnectedContext(ctx)
+		defer cancel()
+
+		err := workflow.ExecuteActivity(disconnectedCtx, activities.DeleteDraftEnvelope, envelopeSummary.EnvelopeID, input.User).Get(disconnectedCtx, nil)
+		if err != nil {
+			workflow.GetLogger(ctx).Error("Unable to discard draft envelope", "envelopeId", envelopeSummary.EnvelopeID, "error", err)
+		}
+
+		return cause
 	}
-	data, err := json.Marshal(ndaFields)
+
+	err = workflow.ExecuteActivity(ctx, activities.FillTemplateFields, envelopeSummary, &input.TemplateFields, input.User).Get(ctx, nil)
 	if err != nil {
-		return "", err
+		return result, discardDraft(err)
 	}
 
-	ndaTemplateFields := &TemplateFieldWrapper{
-		Type: "nda",
-		Data: data,
+	// A void requested while the draft was prepared must not reach the vendor.
+	var voidRequest VoidRequest
+	if workflow.GetSignalChannel(ctx, VoidEnvelopeSignal).ReceiveAsync(&voidRequest) {
+		result.Status = "voided"
+		result.VoidedReason = voidRequest.Reason
+		if result.VoidedReason == "" {
+			result.VoidedReason = DefaultVoidReason
+		}
+
+		return result, discardDraft(nil)
 	}
 
-	err = workflow.ExecuteActivity(ctx, activities.FillTemplateFields, envelopeSummary, ndaTemplateFields, DocusignTestUser).Get(ctx, &envelopeSummary)
+	err = workflow.ExecuteActivity(ctx, activities.SendDraftEnvelope, envelopeSummary, input.User).Get(ctx, &envelopeSummary)
 	if err != nil {
-		return "", err
+		return result, discardDraft(err)
 	}
 
-	err = workflow.ExecuteActivity(ctx, activities.SendDraftEnvelope, envelopeSummary, DocusignTestUser).Get(ctx, &envelopeSummary)
+	result.Status = "sent"
+	result.SentAt = workflow.Now(ctx)
+
+	signingResult, err := waitForSigning(ctx, WaitForSigningInput{
+		EnvelopeID:   envelopeSummary.EnvelopeID,
+		User:         input.User,
+		Deadline:     input.SigningSchedule.Deadline(result.SentAt),
+		VoidOnExpiry: input.SigningSchedule.VoidOnExpiry,
+	}, result.setSigningResult)
 	if err != nil {
-		return "", err
+		return result, err
 	}
 
-	var status string
+	result.setSigningResult(signingResult)
 
-	err = workflow.ExecuteChildWorkflow(ctx, WaitForSigningWorkflow, envelopeSummary.EnvelopeID).Get(ctx, &status)
+	return result, nil
+}
+
+func SendCustomEnvelopeWorkflow(ctx workflow.Context, input CustomEnvelopeInput) (string, error) {
+	ctx = workflow.WithActivityOptions(ctx, defaultActivityOptions())
+
+	var activities *Activities
+
+	input.Status = "sent"
+
+	var envelopeSummary EnvelopeSummary
+	err := workflow.ExecuteActivity(ctx, activities.CreateCustomEnvelope, input).Get(ctx, &envelopeSummary)
+	if err != nil {
+		return "", err
+	}
+
+	signingResult, err := waitForSigning(ctx, WaitForSigningInput{
+		EnvelopeID:   envelopeSummary.EnvelopeID,
+		User:         input.User,
+		Deadline:     input.SigningSchedule.Deadline(workflow.Now(ctx)),
+		VoidOnExpiry: input.SigningSchedule.VoidOnExpiry,
+	}, nil)
 	if err != nil {
 		return "", err
 	}
 
-	return status, nil
+	return signingResult.Status, nil
 }
 
-func WaitForSigningWorkflow(ctx workflow.Context, envelopeID string) (string, error) {
-	ao := workflow.ActivityOptions{
-		StartToCloseTimeout: time.Minute,
+// waitForSigning runs WaitForSigningWorkflow as a child and forwards void
+// requests received by the parent to it, progress reported by the child is
+// passed to onProgress. The child waits for cancellation so a cancelled parent
+// only completes once the envelope has been voided.
+func waitForSigning(ctx workflow.Context, input WaitForSigningInput, onProgress func(WaitForSigningResult)) (WaitForSigningResult, error) {
+	childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+		WorkflowID:          WaitForSigningWorkflowID(input.EnvelopeID),
+		WaitForCancellation: true,
+	})
+
+	child := workflow.ExecuteChildWorkflow(childCtx, WaitForSigningWorkflow, input)
+	voidCh := workflow.GetSignalChannel(ctx, VoidEnvelopeSignal)
+	progressCh := workflow.GetSignalChannel(ctx, SigningProgressSignal)
+
+	var result WaitForSigningResult
+	var err error
+	done := false
+
+	for !done {
+		selector := workflow.NewSelector(ctx)
+
+		selector.AddFuture(child, func(f workflow.Future) {
+			err = f.Get(ctx, &result)
+			done = true
+		})
+
+		selector.AddReceive(voidCh, func(c workflow.ReceiveChannel, more bool) {
+			var request VoidRequest
+			c.Receive(ctx, &request)
+
+			if signalErr := child.SignalChildWorkflow(ctx, VoidEnvelopeSignal, request).Get(ctx, nil); signalErr != nil {
+				workflow.GetLogger(ctx).Error("Unable to forward void request", "envelopeId", input.EnvelopeID, "error", signalErr)
+			}
+		})
+
+		selector.AddReceive(progressCh, func(c workflow.ReceiveChannel, more bool) {
+			var progress WaitForSigningResult
+			c.Receive(ctx, &progress)
+
+			if onProgress != nil {
+				onProgress(progress)
+			}
+		})
+
+		selector.Select(ctx)
 	}
-	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	return result, err
+}
+
+func WaitForSigningWorkflow(ctx workflow.Context, input WaitForSigningInput) (WaitForSigningResult, error) {
+	ctx = workflow.WithActivityOptions(ctx, defaultActivityOptions())
 
 	var activities *Activities
 
-	var envelopeStatus EnvelopeStatus
+	logger := workflow.GetLogger(ctx)
+	signalCh := workflow.GetSignalChannel(ctx, EnvelopeEventSignal)
+	voidCh := workflow.GetSignalChannel(ctx, VoidEnvelopeSignal)
+
+	result := WaitForSigningResult{
+		EnvelopeID: input.EnvelopeID,
+		Recipients: make([]RecipientProgress, 0),
+	}
+
+	// A parent waiting on this envelope is told when the status or the
+	// recipients change so its own status query reflects the signing progress.
+	// Polls that change nothing are not reported, every signal is recorded in
+	// the history of both workflows.
+	parent := workflow.GetInfo(ctx).ParentWorkflowExecution
+	reported := result.progressSnapshot()
+	reportProgress := func(ctx workflow.Context) {
+		if parent == nil || !result.progressChanged(reported) {
+			return
+		}
+
+		err := workflow.SignalExternalWorkflow(ctx, parent.ID, "", SigningProgressSignal, result).Get(ctx, nil)
+		if err != nil {
+			logger.Warn("Unable to report signing progress", "envelopeId", input.EnvelopeID, "error", err)
+			return
+		}
+
+		reported = result.progressSnapshot()
+	}
+
+	err := workflow.SetQueryHandler(ctx, RecipientProgressQuery, func() ([]RecipientProgress, error) {
+		return result.Recipients, nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	err = workflow.SetQueryHandler(ctx, SigningStatusQuery, func() (WaitForSigningResult, error) {
+		return result, nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	err = workflow.SetUpdateHandlerWithOptions(
+		ctx,
+		CorrectRecipientUpdate,
+		func(ctx workflow.Context, correction RecipientCorrection) (AppliedCorrection, error) {
+			ctx = workflow.WithActivityOptions(ctx, defaultActivityOptions())
+
+			i, ok := result.recipient(correction.RecipientID)
+			if !ok {
+				return AppliedCorrection{}, newValidationError("recipient %s not found in envelope %s", correction.RecipientID, input.EnvelopeID)
+			}
+			recipient := result.Recipients[i]
+
+			err := workflow.ExecuteActivity(ctx, activities.CorrectRecipient, input.EnvelopeID, recipient.Type, correction, input.User).Get(ctx, nil)
+			if err != nil {
+				return AppliedCorrection{}, err
+			}
+
+			applied := AppliedCorrection{
+				RecipientCorrection: correction,
+				PreviousName:        recipient.Name,
+				PreviousEmail:       recipient.Email,
+				CorrectedAt:         workflow.Now(ctx),
+			}
+			result.Corrections = append(result.Corrections, applied)
+
+			// The recipient list may have been refreshed while the activity ran.
+			if i, ok := result.recipient(correction.RecipientID); ok {
+				if correction.Name != "" {
+					result.Recipients[i].Name = correction.Name
+				}
+				if correction.Email != "" {
+					result.Recipients[i].Email = correction.Email
+				}
+			}
+
+			logger.Info("Corrected recipient", "envelopeId", input.EnvelopeID, "recipientId", correction.RecipientID)
+
+			reportProgress(ctx)
+
+			return applied, nil
+		},
+		workflow.UpdateHandlerOptions{
+			Validator: func(ctx workflow.Context, correction RecipientCorrection) error {
+				if err := correction.Validate(); err != nil {
+					return err
+				}
+
+				_, err := result.pendingRecipient(correction.RecipientID)
+				return err
+			},
+		},
+	)
+	if err != nil {
+		return result, err
+	}
+
+	err = workflow.SetUpdateHandlerWithOptions(
+		ctx,
+		RecipientViewUpdate,
+		func(ctx workflow.Context, viewInput RecipientViewInput) (RecipientView, error) {
+			ctx = workflow.WithActivityOptions(ctx, defaultActivityOptions())
+
+			recipient, err := result.pendingRecipient(viewInput.RecipientID)
+			if err != nil {
+				return RecipientView{}, err
+			}
+
+			var view RecipientView
+			err = workflow.ExecuteActivity(ctx, activities.CreateRecipientView, input.EnvelopeID, recipient, viewInput.ReturnURL, input.User).Get(ctx, &view)
+			if err != nil {
+				return RecipientView{}, err
+			}
+
+			return view, nil
+		},
+		workflow.UpdateHandlerOptions{
+			Validator: func(ctx workflow.Context, viewInput RecipientViewInput) error {
+				if err := viewInput.Validate(); err != nil {
+					return err
+				}
+
+				recipient, err := result.pendingRecipient(viewInput.RecipientID)
+				if err != nil {
+					return err
+				}
+
+				if !recipient.Embedded() {
+					return fmt.Errorf("recipient %s has no client user ID and signs by email", viewInput.RecipientID)
+				}
+
+				return nil
+			},
+		},
+	)
+	if err != nil {
+		return result, err
+	}
+
+	voidEnvelope := func(ctx workflow.Context, reason string) error {
+		err := workflow.ExecuteActivity(ctx, activities.VoidEnvelope, input.EnvelopeID, reason, input.User).Get(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		result.Status = "voided"
+		result.VoidedReason = reason
+		return nil
+	}
+
+	voidOnCancel := func() error {
+		disconnectedCtx, cancel := workflow.NewDisconnectedContext(ctx)
+		defer cancel()
+
+		if err := voidEnvelope(disconnectedCtx, CancelledVoidReason); err != nil {
+			logger.Error("Unable to void envelope of cancelled workflow", "envelopeId", input.EnvelopeID, "error", err)
+		}
+
+		return ctx.Err()
+	}
+
+	// The deadline timer lives for the whole workflow, once it fires the status
+	// is polled a last time so an envelope finished just before the deadline is
+	// not reported as expired.
+	var deadline workflow.Future
+	expired := false
+	if !input.Deadline.IsZero() {
+		remaining := input.Deadline.Sub(workflow.Now(ctx))
+		if remaining > 0 {
+			deadline = workflow.NewTimer(ctx, remaining)
+		} else {
+			expired = true
+		}
+	}
+
+	poll := true
+	voidReason := ""
 
 	for {
-		err := workflow.ExecuteActivity(ctx, activities.GetEnvelopeStatus, envelopeID, DocusignTestUser).Get(ctx, &envelopeStatus)
+		if voidReason != "" {
+			// Voiding fails when the envelope was finished just before the
+			// request arrived, the poll below picks up its actual status.
+			if err := voidEnvelope(ctx, voidReason); err != nil {
+				if ctx.Err() != nil {
+					return result, voidOnCancel()
+				}
+				logger.Warn("Unable to void envelope", "envelopeId", input.EnvelopeID, "error", err)
+				poll = true
+			}
+			voidReason = ""
+		}
+
+		if poll {
+			var envelopeStatus EnvelopeStatus
+			err := workflow.ExecuteActivity(ctx, activities.GetEnvelopeStatus, input.EnvelopeID, input.User).Get(ctx, &envelopeStatus)
+			if err != nil {
+				if ctx.Err() != nil {
+					return result, voidOnCancel()
+				}
+				return result, err
+			}
+
+			result.Status = envelopeStatus.Status
+			result.SentAt = parseDocusignTime(envelopeStatus.SentDateTime)
+			result.CompletedAt = parseDocusignTime(envelopeStatus.CompletedDateTime)
+		}
+
+		var progress []RecipientProgress
+		err := workflow.ExecuteActivity(ctx, activities.GetEnvelopeRecipients, input.EnvelopeID, input.User).Get(ctx, &progress)
 		if err != nil {
-			return "", err
+			logger.Warn("Unable to refresh recipient progress", "envelopeId", input.EnvelopeID, "error", err)
+		} else {
+			result.setRecipients(progress)
+		}
+
+		if isFinalEnvelopeStatus(result.Status) {
+			break
 		}
 
-		if envelopeStatus.Status == "completed" || envelopeStatus.Status == "voided" || envelopeStatus.Status == "declined" {
+		reportProgress(ctx)
+
+		if expired {
+			if input.VoidOnExpiry {
+				if err := voidEnvelope(ctx, ExpiredVoidReason); err != nil {
+					if ctx.Err() != nil {
+						return result, voidOnCancel()
+					}
+					logger.Warn("Unable to void expired envelope", "envelopeId", input.EnvelopeID, "error", err)
+				}
+			}
+
+			result.Status = ExpiredEnvelopeStatus
+			result.ExpiredAt = input.Deadline
 			break
 		}
 
-		workflow.Sleep(ctx, 1*time.Minute)
+		poll = false
+
+		timerCtx, cancelTimer := workflow.WithCancel(ctx)
+		selector := workflow.NewSelector(ctx)
+
+		selector.AddReceive(signalCh, func(c workflow.ReceiveChannel, more bool) {
+			var event EnvelopeEvent
+			c.Receive(ctx, &event)
+			logger.Info("Received envelope event", "event", event.Event, "status", event.Status)
+			if event.Status != "" {
+				result.Status = event.Status
+				// Confirm final statuses with DocuSign, which also provides the
+				// completion time.
+				poll = isFinalEnvelopeStatus(event.Status)
+			}
+		})
+
+		selector.AddReceive(voidCh, func(c workflow.ReceiveChannel, more bool) {
+			var request VoidRequest
+			c.Receive(ctx, &request)
+			voidReason = request.Reason
+			if voidReason == "" {
+				voidReason = DefaultVoidReason
+			}
+		})
+
+		selector.AddReceive(ctx.Done(), func(c workflow.ReceiveChannel, more bool) {})
+
+		selector.AddFuture(workflow.NewTimer(timerCtx, EnvelopePollFallbackInterval), func(f workflow.Future) {
+			poll = true
+		})
+
+		if deadline != nil {
+			selector.AddFuture(deadline, func(f workflow.Future) {
+				expired = true
+				poll = true
+			})
+		}
+
+		selector.Select(ctx)
+		cancelTimer()
+
+		if ctx.Err() != nil {
+			return result, voidOnCancel()
+		}
+	}
+
+	// Let corrections that are still in flight record their outcome.
+	err = workflow.Await(ctx, func() bool {
+		return workflow.AllHandlersFinished(ctx)
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if result.Status == "completed" {
+		archiveCtx := workflow.WithActivityOptions(ctx, archiveActivityOptions())
+
+		err := workflow.ExecuteActivity(archiveCtx, activities.ArchiveEnvelopeDocuments, input.EnvelopeID, input.User).Get(ctx, &result.Documents)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+func DraftEnvelopeSweeperWorkflow(ctx workflow.Context, input DraftSweeperInput) (int, error) {
+	ctx = workflow.WithActivityOptions(ctx, defaultActivityOptions())
+
+	var activities *Activities
+
+	logger := workflow.GetLogger(ctx)
+	input = input.withDefaults()
+
+	var stale []EnvelopeListItem
+	err := workflow.ExecuteActivity(ctx, activities.ListStaleDraftEnvelopes, StaleDraftQuery{
+		User:     input.User,
+		MaxAge:   input.MaxAge,
+		Lookback: input.Lookback,
+	}).Get(ctx, &stale)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, envelope := range stale {
+		err := workflow.ExecuteActivity(ctx, activities.DeleteDraftEnvelope, envelope.EnvelopeID, input.User).Get(ctx, nil)
+		if err != nil {
+			logger.Error("Unable to purge stale draft envelope", "envelopeId", envelope.EnvelopeID, "error", err)
+			continue
+		}
+		purged++
+	}
+
+	logger.Info("Purged stale draft envelopes", "purged", purged, "found", len(stale))
+
+	if input.Interval <= 0 {
+		return purged, nil
+	}
+
+	if err := workflow.Sleep(ctx, input.Interval); err != nil {
+		return purged, err
 	}
 
-	return envelopeStatus.Status, nil
+	return purged, workflow.NewContinueAsNewError(ctx, DraftEnvelopeSweeperWorkflow, input)
+}
+
+func isFinalEnvelopeStatus(status string) bool {
+	return status == "completed" || status == "voided" || status == "declined"
 }
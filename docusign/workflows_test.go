@@ -0,0 +1,183 @@
+package docusign
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func TestWaitForSigningWorkflowPicksUpStatusWhenVoidFails(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+
+	var activities *Activities
+	env.RegisterActivity(activities)
+
+	env.OnActivity(activities.GetEnvelopeStatus, mock.Anything, "envelope", mock.Anything).Return(EnvelopeStatus{Status: "sent"}, nil).Once()
+	env.OnActivity(activities.GetEnvelopeStatus, mock.Anything, "envelope", mock.Anything).Return(EnvelopeStatus{Status: "completed"}, nil)
+	env.OnActivity(activities.GetEnvelopeRecipients, mock.Anything, "envelope", mock.Anything).Return([]RecipientProgress{}, nil)
+	env.OnActivity(activities.VoidEnvelope, mock.Anything, "envelope", "deal is off", mock.Anything).
+		Return(temporal.NewNonRetryableApplicationError("envelope is completed", ValidationErrorType, nil)).Once()
+	env.OnActivity(activities.ArchiveEnvelopeDocuments, mock.Anything, "envelope", mock.Anything).Return([]ArchivedDocument{{Kind: "combined"}}, nil).Once()
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(VoidEnvelopeSignal, VoidRequest{Reason: "deal is off"})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(WaitForSigningWorkflow, WaitForSigningInput{EnvelopeID: "envelope"})
+
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatal(err)
+	}
+
+	var result WaitForSigningResult
+	if err := env.GetWorkflowResult(&result); err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Status != "completed" || len(result.Documents) != 1 {
+		t.Fatalf("expected the completed envelope to be archived, got %+v", result)
+	}
+
+	env.AssertExpectations(t)
+}
+
+func TestSendNdaWorkflowDiscardsDraftVoidedBeforeSending(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+
+	var activities *Activities
+	env.RegisterActivity(activities)
+
+	env.OnActivity(activities.CreateNdaEnvelope, mock.Anything, mock.Anything).Return(EnvelopeSummary{EnvelopeID: "envelope", Status: "created"}, nil)
+	env.OnActivity(activities.FillTemplateFields, mock.Anything, mock.Anything, mock.Anything, mock.Anything).After(time.Minute).Return(nil)
+	env.OnActivity(activities.DeleteDraftEnvelope, mock.Anything, "envelope", mock.Anything).Return(nil).Once()
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(VoidEnvelopeSignal, VoidRequest{Reason: "deal is off"})
+	}, 30*time.Second)
+
+	env.ExecuteWorkflow(SendNdaWorkflow, SendNdaWorkflowInput{})
+
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatal(err)
+	}
+
+	var result SendNdaResult
+	if err := env.GetWorkflowResult(&result); err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Status != "voided" || result.VoidedReason != "deal is off" {
+		t.Fatalf("expected the draft to be voided, got %+v", result)
+	}
+
+	env.AssertExpectations(t)
+	env.AssertNotCalled(t, "SendDraftEnvelope", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSendNdaWorkflowQueryReflectsSigningProgress(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+
+	var activities *Activities
+	env.RegisterActivity(activities)
+	env.RegisterWorkflow(WaitForSigningWorkflow)
+
+	signer := RecipientProgress{RecipientID: "1", Type: SignerRecipientType, Name: "Signer", Status: RecipientStatusDelivered}
+
+	env.OnActivity(activities.CreateNdaEnvelope, mock.Anything, mock.Anything).Return(EnvelopeSummary{EnvelopeID: "envelope", Status: "created"}, nil)
+	env.OnActivity(activities.FillTemplateFields, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(activities.SendDraftEnvelope, mock.Anything, mock.Anything, mock.Anything).Return(EnvelopeSummary{EnvelopeID: "envelope", Status: "sent"}, nil)
+	env.OnActivity(activities.GetEnvelopeStatus, mock.Anything, "envelope", mock.Anything).Return(EnvelopeStatus{Status: "sent"}, nil).Once()
+	env.OnActivity(activities.GetEnvelopeStatus, mock.Anything, "envelope", mock.Anything).Return(EnvelopeStatus{Status: "completed"}, nil)
+	env.OnActivity(activities.GetEnvelopeRecipients, mock.Anything, "envelope", mock.Anything).Return([]RecipientProgress{signer}, nil)
+	env.OnActivity(activities.ArchiveEnvelopeDocuments, mock.Anything, "envelope", mock.Anything).Return([]ArchivedDocument{}, nil)
+
+	queried := false
+	env.RegisterDelayedCallback(func() {
+		value, err := env.QueryWorkflow(SendNdaResultQuery)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var result SendNdaResult
+		if err := value.Get(&result); err != nil {
+			t.Fatal(err)
+		}
+
+		if result.EnvelopeID != "envelope" || result.Status != "sent" || len(result.Recipients) != 1 || result.Recipients[0].Status != RecipientStatusDelivered {
+			t.Fatalf("expected the query to reflect the signing progress, got %+v", result)
+		}
+		queried = true
+	}, time.Minute)
+
+	env.ExecuteWorkflow(SendNdaWorkflow, SendNdaWorkflowInput{})
+
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !queried {
+		t.Fatal("expected the workflow to be queried while signing")
+	}
+
+	var result SendNdaResult
+	if err := env.GetWorkflowResult(&result); err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Status != "completed" || result.SentAt.IsZero() {
+		t.Fatalf("expected the completed signing result, got %+v", result)
+	}
+}
+
+// countProgressReportsWorkflow waits for signing like SendNdaWorkflow does and
+// returns how often the signing workflow reported its progress.
+func countProgressReportsWorkflow(ctx workflow.Context, input WaitForSigningInput) (int, error) {
+	reports := 0
+	_, err := waitForSigning(ctx, input, func(WaitForSigningResult) {
+		reports++
+	})
+
+	return reports, err
+}
+
+func TestWaitForSigningWorkflowOnlyReportsChangedProgress(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+
+	var activities *Activities
+	env.RegisterActivity(activities)
+	env.RegisterWorkflow(WaitForSigningWorkflow)
+	env.RegisterWorkflow(countProgressReportsWorkflow)
+
+	delivered := RecipientProgress{RecipientID: "1", Type: SignerRecipientType, Status: RecipientStatusDelivered}
+	signed := delivered
+	signed.Status = RecipientStatusSigned
+
+	env.OnActivity(activities.GetEnvelopeStatus, mock.Anything, "envelope", mock.Anything).Return(EnvelopeStatus{Status: "sent"}, nil).Times(4)
+	env.OnActivity(activities.GetEnvelopeStatus, mock.Anything, "envelope", mock.Anything).Return(EnvelopeStatus{Status: "completed"}, nil)
+	env.OnActivity(activities.GetEnvelopeRecipients, mock.Anything, "envelope", mock.Anything).Return([]RecipientProgress{delivered}, nil).Times(3)
+	env.OnActivity(activities.GetEnvelopeRecipients, mock.Anything, "envelope", mock.Anything).Return([]RecipientProgress{signed}, nil)
+	env.OnActivity(activities.ArchiveEnvelopeDocuments, mock.Anything, "envelope", mock.Anything).Return([]ArchivedDocument{}, nil)
+
+	env.ExecuteWorkflow(countProgressReportsWorkflow, WaitForSigningInput{EnvelopeID: "envelope"})
+
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatal(err)
+	}
+
+	var reports int
+	if err := env.GetWorkflowResult(&reports); err != nil {
+		t.Fatal(err)
+	}
+
+	if reports != 2 {
+		t.Fatalf("expected a report for the first refresh and the signed recipient, got %d", reports)
+	}
+}
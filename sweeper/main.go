@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"github.com/alessandrr/temporal-go-docusign/docusign"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
+)
+
+func main() {
+	user := flag.String("user", "", "ID of the DocuSign user to impersonate")
+	account := flag.String("account", "", "DocuSign account ID, defaults to the user's default account")
+	maxAge := flag.Duration("max-age", docusign.DefaultDraftMaxAge, "age after which draft envelopes are purged")
+	interval := flag.Duration("interval", 6*time.Hour, "time between sweeps, 0 runs a single sweep")
+	flag.Parse()
+
+	if *user == "" {
+		log.Fatalln("A DocuSign user is required")
+	}
+
+	config, err := docusign.ReadConfig()
+	if err != nil {
+		log.Fatalln("Unable to load config", err)
+	}
+
+	c, err := client.Dial(client.Options{
+		HostPort:  config.TemporalHost,
+		Namespace: config.TemporalNamespace,
+	})
+	if err != nil {
+		log.Fatalln("Unable to create client", err)
+	}
+	defer c.Close()
+
+	input := docusign.DraftSweeperInput{
+		User:     docusign.DocusignUser{UserID: *user, AccountID: *account},
+		MaxAge:   *maxAge,
+		Interval: *interval,
+	}
+
+	workflowOptions := client.StartWorkflowOptions{
+		TaskQueue:             config.TaskQueue,
+		ID:                    "draft-envelope-sweeper-" + input.User.String(),
+		WorkflowIDReusePolicy: enums.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE,
+	}
+
+	we, err := c.ExecuteWorkflow(context.Background(), workflowOptions, docusign.DraftEnvelopeSweeperWorkflow, input)
+	if err != nil {
+		log.Fatalln("Unable to start sweeper workflow", err)
+	}
+
+	log.Println("Started sweeper workflow", we.GetID(), we.GetRunID())
+}
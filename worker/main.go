@@ -3,39 +3,47 @@ package main
 import (
 	"log"
 	"net/http"
-	"time"
 
 	"github.com/alessandrr/temporal-go-docusign/docusign"
-	"github.com/joho/godotenv"
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/worker"
 )
 
 func main() {
-	err := godotenv.Load("../.env")
+	config, err := docusign.LoadConfig()
 	if err != nil {
-		log.Fatal("Error loading .env file")
+		log.Fatalln("Unable to load config", err)
 	}
 
-	cache := docusign.NewKeysCache(1 * time.Minute)
+	cache, err := docusign.NewTokenCache(config)
+	if err != nil {
+		log.Fatalln("Unable to create token cache", err)
+	}
 	defer cache.Stop()
 
-	apiClient := docusign.NewAPIClient(http.Header{}, &http.Client{})
-	authService := docusign.NewAuthService(cache, apiClient, docusign.LoadConfig())
-	activities := docusign.NewActivities(apiClient, authService)
+	rateLimiter := docusign.NewRateLimiter(config.RateLimitThreshold, docusign.DefaultRateLimitMaxWait)
+	apiClient := docusign.NewAPIClient(&http.Client{}, rateLimiter)
+	authService := docusign.NewAuthService(cache, apiClient, config)
+	documentStore := docusign.NewLocalDocumentStore(config.ArchiveDir)
+	activities := docusign.NewActivities(authService, docusign.NewDefaultTemplateRegistry(), documentStore, config.DocumentDir)
 
-	c, err := client.Dial(client.Options{})
+	c, err := client.Dial(client.Options{
+		HostPort:  config.TemporalHost,
+		Namespace: config.TemporalNamespace,
+	})
 	if err != nil {
 		log.Fatalln("Unable to create Temporal client", err)
 	}
 
 	defer c.Close()
 
-	w := worker.New(c, "nda", worker.Options{})
+	w := worker.New(c, config.TaskQueue, worker.Options{})
 
 	w.RegisterActivity(activities)
 	w.RegisterWorkflow(docusign.SendNdaWorkflow)
+	w.RegisterWorkflow(docusign.SendCustomEnvelopeWorkflow)
 	w.RegisterWorkflow(docusign.WaitForSigningWorkflow)
+	w.RegisterWorkflow(docusign.DraftEnvelopeSweeperWorkflow)
 
 	err = w.Run(worker.InterruptCh())
 	if err != nil {